@@ -0,0 +1,116 @@
+// Command apikey manages the API keys checked when AUTH_ENABLED=true.
+//
+//	apikey create -name NAME -scopes quotes:read,quotes:write
+//	apikey list
+//	apikey revoke ID
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/bootstrap"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/logx"
+	"fxrates-service/internal/infrastructure/pg"
+
+	"github.com/joho/godotenv"
+)
+
+func init() { _ = godotenv.Load() }
+
+const usage = `usage:
+  apikey create -name NAME -scopes SCOPE[,SCOPE...]   scopes: quotes:read, quotes:write, admin
+  apikey list
+  apikey revoke ID`
+
+func main() {
+	if err := run(context.Background(), os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "apikey:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	cfg := bootstrap.ProvideConfig()
+	db, cleanup, err := bootstrap.ProvideDB(ctx, logx.L(), cfg)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	keys := application.NewAPIKeys(pg.NewAPIKeyRepo(db))
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("create", flag.ContinueOnError)
+		name := fs.String("name", "", "who or what the key is for")
+		scopes := fs.String("scopes", "", "comma-separated scopes")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		var ss []domain.Scope
+		for _, s := range strings.Split(*scopes, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				ss = append(ss, domain.Scope(s))
+			}
+		}
+		k, secret, err := keys.Create(ctx, *name, ss)
+		if errors.Is(err, application.ErrBadRequest) {
+			return errors.New("a name and at least one valid scope are required\n" + usage)
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("id:     %s\nscopes: %s\nkey:    %s\n", k.ID, joinScopes(k.Scopes), secret)
+		fmt.Fprintln(os.Stderr, "The key is shown only once; store it now.")
+		return nil
+	case "list":
+		list, err := keys.List(ctx)
+		if err != nil {
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "ID\tNAME\tPREFIX\tSCOPES\tCREATED\tREVOKED")
+		for _, k := range list {
+			revoked := "-"
+			if k.RevokedAt != nil {
+				revoked = k.RevokedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", k.ID, k.Name, k.Prefix, joinScopes(k.Scopes), k.CreatedAt.Format(time.RFC3339), revoked)
+		}
+		return tw.Flush()
+	case "revoke":
+		if len(args) != 2 {
+			return errors.New(usage)
+		}
+		err := keys.Revoke(ctx, args[1])
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("no active key with id %s", args[1])
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Println("revoked", args[1])
+		return nil
+	default:
+		return errors.New(usage)
+	}
+}
+
+func joinScopes(scopes []domain.Scope) string {
+	ss := make([]string, len(scopes))
+	for i, s := range scopes {
+		ss[i] = string(s)
+	}
+	return strings.Join(ss, ",")
+}
@@ -0,0 +1,124 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// apiKeyPrefix marks the secrets issued by this service so stray tokens fail fast.
+const apiKeyPrefix = "fxr_"
+
+// apiKeyShownPrefix is how much of a key listings show.
+const apiKeyShownPrefix = len(apiKeyPrefix) + 6
+
+// Authenticator resolves an API key secret to the key it belongs to.
+type Authenticator interface {
+	Authenticate(ctx context.Context, secret string) (domain.APIKey, error)
+}
+
+// APIKeys issues, lists, revokes and checks API keys. Only a SHA-256 hash of each
+// secret is stored; the secret itself is returned once, when the key is created.
+type APIKeys struct {
+	repo APIKeyRepo
+	now  ClockFunc
+}
+
+func NewAPIKeys(repo APIKeyRepo) *APIKeys {
+	return &APIKeys{repo: repo, now: time.Now}
+}
+
+// Create issues a key with the given scopes and returns it together with its secret.
+func (a *APIKeys) Create(ctx context.Context, name string, scopes []domain.Scope) (domain.APIKey, string, error) {
+	if strings.TrimSpace(name) == "" || len(scopes) == 0 {
+		return domain.APIKey{}, "", ErrBadRequest
+	}
+	for _, s := range scopes {
+		if !s.Valid() {
+			return domain.APIKey{}, "", ErrBadRequest
+		}
+	}
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return domain.APIKey{}, "", err
+	}
+	secret := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	k := domain.APIKey{
+		Name:      name,
+		Prefix:    secret[:apiKeyShownPrefix],
+		Scopes:    slices.Compact(slices.Sorted(slices.Values(scopes))),
+		CreatedAt: a.now().UTC(),
+	}
+	id, err := a.repo.Create(ctx, k, hashAPIKey(secret))
+	if err != nil {
+		return domain.APIKey{}, "", err
+	}
+	k.ID = id
+	return k, secret, nil
+}
+
+func (a *APIKeys) List(ctx context.Context) ([]domain.APIKey, error) {
+	return a.repo.List(ctx)
+}
+
+// Revoke disables a key at once. Returns domain.ErrNotFound when no active key has id.
+func (a *APIKeys) Revoke(ctx context.Context, id string) error {
+	if _, err := uuid.Parse(id); err != nil {
+		return domain.ErrNotFound
+	}
+	ok, err := a.repo.Revoke(ctx, id, a.now().UTC())
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// Authenticate returns the active key for secret, or ErrUnauthenticated.
+func (a *APIKeys) Authenticate(ctx context.Context, secret string) (domain.APIKey, error) {
+	if !strings.HasPrefix(secret, apiKeyPrefix) {
+		return domain.APIKey{}, ErrUnauthenticated
+	}
+	k, err := a.repo.GetByHash(ctx, hashAPIKey(secret))
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.APIKey{}, ErrUnauthenticated
+	}
+	if err != nil {
+		return domain.APIKey{}, err
+	}
+	if k.RevokedAt != nil {
+		return domain.APIKey{}, ErrUnauthenticated
+	}
+	return k, nil
+}
+
+// Secrets are long and random, so a plain hash is enough; there is nothing to brute-force.
+func hashAPIKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+type apiKeyCtxKey struct{}
+
+// ContextWithAPIKey attaches the authenticated key to ctx.
+func ContextWithAPIKey(ctx context.Context, k domain.APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyCtxKey{}, k)
+}
+
+// APIKeyFromContext returns the key that authenticated the current request, if any.
+func APIKeyFromContext(ctx context.Context) (domain.APIKey, bool) {
+	k, ok := ctx.Value(apiKeyCtxKey{}).(domain.APIKey)
+	return k, ok
+}
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_APIKeys_CreateAuthenticateRevoke(t *testing.T) {
+	t.Parallel()
+	repo := &fakeAPIKeyRepo{}
+	keys := NewAPIKeys(repo)
+	ctx := context.Background()
+
+	k, secret, err := keys.Create(ctx, "desk", []domain.Scope{domain.ScopeQuotesWrite, domain.ScopeQuotesRead, domain.ScopeQuotesWrite})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(secret, k.Prefix))
+	require.Equal(t, []domain.Scope{domain.ScopeQuotesRead, domain.ScopeQuotesWrite}, k.Scopes)
+	require.NotContains(t, repo.hashes, secret, "only the hash is stored")
+
+	got, err := keys.Authenticate(ctx, secret)
+	require.NoError(t, err)
+	require.Equal(t, k.ID, got.ID)
+	require.True(t, got.Allows(domain.ScopeQuotesRead))
+	require.False(t, got.Allows(domain.ScopeAdmin))
+
+	for _, bad := range []string{"", "nope", secret + "x"} {
+		_, err = keys.Authenticate(ctx, bad)
+		require.ErrorIs(t, err, ErrUnauthenticated)
+	}
+
+	require.NoError(t, keys.Revoke(ctx, k.ID))
+	_, err = keys.Authenticate(ctx, secret)
+	require.ErrorIs(t, err, ErrUnauthenticated)
+	require.ErrorIs(t, keys.Revoke(ctx, k.ID), domain.ErrNotFound)
+	require.ErrorIs(t, keys.Revoke(ctx, "not-a-uuid"), domain.ErrNotFound)
+}
+
+func Test_APIKeys_CreateValidation(t *testing.T) {
+	t.Parallel()
+	keys := NewAPIKeys(&fakeAPIKeyRepo{})
+	ctx := context.Background()
+
+	_, _, err := keys.Create(ctx, "", []domain.Scope{domain.ScopeAdmin})
+	require.ErrorIs(t, err, ErrBadRequest)
+	_, _, err = keys.Create(ctx, "desk", nil)
+	require.ErrorIs(t, err, ErrBadRequest)
+	_, _, err = keys.Create(ctx, "desk", []domain.Scope{"quotes:delete"})
+	require.ErrorIs(t, err, ErrBadRequest)
+}
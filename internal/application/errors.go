@@ -4,3 +4,6 @@ import "errors"
 
 var ErrConflict = errors.New("conflict")
 var ErrBadRequest = errors.New("bad request")
+var ErrIdempotencyMismatch = errors.New("idempotency key reused with a different request")
+var ErrUnauthenticated = errors.New("unauthenticated")
+var ErrDispatchUnavailable = errors.New("update could not be dispatched")
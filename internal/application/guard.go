@@ -0,0 +1,58 @@
+package application
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"fxrates-service/internal/domain"
+)
+
+// QuoteGuard rejects implausible provider quotes before they reach the quotes table.
+type QuoteGuard struct {
+	// MaxDeviationPct is the largest accepted move from the last stored price, in percent.
+	// Zero disables the deviation check.
+	MaxDeviationPct float64
+}
+
+// QuoteRejectedError carries the reason a quote failed the guard. Its message is stored
+// as the job error, so it starts with the stable reason code.
+type QuoteRejectedError struct {
+	Reason domain.QuoteRejectReason
+	Detail string
+}
+
+func (e *QuoteRejectedError) Error() string {
+	return fmt.Sprintf("quote rejected: %s: %s", e.Reason, e.Detail)
+}
+
+// Check validates q against the last accepted quote for the pair; last is nil when none exists.
+func (g QuoteGuard) Check(q domain.Quote, last *domain.Quote) *QuoteRejectedError {
+	if q.Price <= 0 || math.IsNaN(q.Price) || math.IsInf(q.Price, 0) {
+		return &QuoteRejectedError{
+			Reason: domain.QuoteRejectNonPositivePrice,
+			Detail: fmt.Sprintf("price %v is not a positive number", q.Price),
+		}
+	}
+	if last == nil {
+		return nil
+	}
+	if q.UpdatedAt.Before(last.UpdatedAt) {
+		return &QuoteRejectedError{
+			Reason: domain.QuoteRejectOutOfOrder,
+			Detail: fmt.Sprintf("quoted at %s, before last accepted %s",
+				q.UpdatedAt.UTC().Format(time.RFC3339), last.UpdatedAt.UTC().Format(time.RFC3339)),
+		}
+	}
+	if g.MaxDeviationPct > 0 && last.Price > 0 {
+		dev := math.Abs(q.Price-last.Price) / last.Price * 100
+		if dev > g.MaxDeviationPct {
+			return &QuoteRejectedError{
+				Reason: domain.QuoteRejectDeviation,
+				Detail: fmt.Sprintf("price %v deviates %.2f%% from last %v (max %v%%)",
+					q.Price, dev, last.Price, g.MaxDeviationPct),
+			}
+		}
+	}
+	return nil
+}
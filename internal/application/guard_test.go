@@ -0,0 +1,45 @@
+package application
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QuoteGuard_Check(t *testing.T) {
+	t.Parallel()
+	ts := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	last := &domain.Quote{Pair: "EUR/USD", Price: 1.0, UpdatedAt: ts}
+	g := QuoteGuard{MaxDeviationPct: 5}
+
+	cases := []struct {
+		name string
+		q    domain.Quote
+		last *domain.Quote
+		want domain.QuoteRejectReason
+	}{
+		{"first quote accepted", domain.Quote{Price: 9, UpdatedAt: ts}, nil, ""},
+		{"within deviation", domain.Quote{Price: 1.04, UpdatedAt: ts.Add(time.Minute)}, last, ""},
+		{"same timestamp accepted", domain.Quote{Price: 1.0, UpdatedAt: ts}, last, ""},
+		{"zero price", domain.Quote{Price: 0, UpdatedAt: ts}, nil, domain.QuoteRejectNonPositivePrice},
+		{"negative price", domain.Quote{Price: -1, UpdatedAt: ts}, last, domain.QuoteRejectNonPositivePrice},
+		{"NaN price", domain.Quote{Price: math.NaN(), UpdatedAt: ts}, last, domain.QuoteRejectNonPositivePrice},
+		{"jump", domain.Quote{Price: 1.2, UpdatedAt: ts.Add(time.Minute)}, last, domain.QuoteRejectDeviation},
+		{"older than last", domain.Quote{Price: 1.0, UpdatedAt: ts.Add(-time.Minute)}, last, domain.QuoteRejectOutOfOrder},
+	}
+	for _, tc := range cases {
+		rej := g.Check(tc.q, tc.last)
+		if tc.want == "" {
+			require.Nil(t, rej, tc.name)
+			continue
+		}
+		require.NotNil(t, rej, tc.name)
+		require.Equal(t, tc.want, rej.Reason, tc.name)
+	}
+
+	require.Nil(t, QuoteGuard{}.Check(domain.Quote{Price: 100, UpdatedAt: ts}, last), "deviation check disabled")
+}
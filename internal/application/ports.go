@@ -2,14 +2,20 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"fxrates-service/internal/domain"
 )
 
 type QuoteRepo interface {
 	GetLast(ctx context.Context, pair string) (domain.Quote, error)
+	// GetMany returns the stored quotes of pairs in one query; pairs without a quote are omitted.
+	GetMany(ctx context.Context, pairs []string) ([]domain.Quote, error)
 	Upsert(ctx context.Context, q domain.Quote) error
 	AppendHistory(ctx context.Context, q domain.QuoteHistory) error
+	// HistorySince returns up to limit history rows with an id above afterID, oldest first,
+	// restricted to pairs unless pairs is empty.
+	HistorySince(ctx context.Context, afterID int64, pairs []string, limit int) ([]domain.QuoteHistory, error)
 }
 
 type UpdateJobRepo interface {
@@ -17,13 +23,97 @@ type UpdateJobRepo interface {
 	GetByID(ctx context.Context, id string) (domain.QuoteUpdate, error)
 	UpdateStatus(ctx context.Context, id string, status domain.QuoteUpdateStatus, errMsg *string) error
 	ClaimQueued(ctx context.Context, limit int) ([]struct{ ID, Pair string }, error)
+	// ClaimByID moves a queued job to processing; false means it is no longer queued.
+	ClaimByID(ctx context.Context, id string) (bool, error)
+	// CancelQueued moves a queued job to canceled; false means it is no longer queued.
+	CancelQueued(ctx context.Context, id string) (bool, error)
+	// RequeueFailed moves a failed job back to queued; false means it has not failed.
+	RequeueFailed(ctx context.Context, id string) (bool, error)
+	// List returns jobs matching f, newest first.
+	List(ctx context.Context, f domain.QuoteUpdateFilter) ([]domain.QuoteUpdate, error)
+	// CountByStatus counts jobs matching f, ignoring its Status, Limit and After.
+	CountByStatus(ctx context.Context, f domain.QuoteUpdateFilter) (map[domain.QuoteUpdateStatus]int64, error)
+}
+
+// QuoteFeed delivers stored quote changes, from any replica, to in-process subscribers.
+type QuoteFeed interface {
+	// Subscribe returns a channel of changes in commit order and a func that releases it.
+	// The channel is closed when the subscriber falls behind or changes may have been
+	// missed; the subscriber then resumes from the history.
+	Subscribe() (<-chan domain.QuoteHistory, func())
+}
+
+// UpdateNotifier wakes up callers waiting for an update job to change status.
+type UpdateNotifier interface {
+	// Subscribe returns a channel that receives a value after job id changes status,
+	// and a func that releases the subscription.
+	Subscribe(id string) (<-chan struct{}, func())
+}
+
+// AttemptRepo keeps the processing history of update jobs.
+type AttemptRepo interface {
+	// Start records a new attempt and returns its 1-based number.
+	Start(ctx context.Context, updateID, worker string, at time.Time) (int, error)
+	Finish(ctx context.Context, updateID string, number int, at time.Time, errMsg *string) error
+	// List returns the attempts of an update, oldest first.
+	List(ctx context.Context, updateID string) ([]domain.QuoteUpdateAttempt, error)
+}
+
+// UpdateBatchRepo groups update jobs created by one batch request.
+type UpdateBatchRepo interface {
+	Create(ctx context.Context) (string, error)
+	// AddQueued creates a queued update job that belongs to the batch.
+	AddQueued(ctx context.Context, batchID, pair string) (string, error)
+	// Get returns the batch with its member updates ordered by pair.
+	Get(ctx context.Context, id string) (domain.QuoteUpdateBatch, error)
+}
+
+// QuarantineRepo stores quotes rejected by the QuoteGuard until an operator releases them.
+type QuarantineRepo interface {
+	Add(ctx context.Context, q domain.QuarantinedQuote) (int64, error)
+	Get(ctx context.Context, id int64) (domain.QuarantinedQuote, error)
+	// ListPending returns unreleased entries, newest first; an empty pair matches all pairs.
+	ListPending(ctx context.Context, pair string, limit int) ([]domain.QuarantinedQuote, error)
+	// MarkReleased reports false when the entry was already released.
+	MarkReleased(ctx context.Context, id int64, at time.Time) (bool, error)
+}
+
+// APIKeyRepo stores API keys by the hash of their secret.
+type APIKeyRepo interface {
+	Create(ctx context.Context, k domain.APIKey, hash string) (string, error)
+	GetByHash(ctx context.Context, hash string) (domain.APIKey, error)
+	// List returns every key, revoked ones included, oldest first.
+	List(ctx context.Context) ([]domain.APIKey, error)
+	// Revoke reports false when the key does not exist or was already revoked.
+	Revoke(ctx context.Context, id string, at time.Time) (bool, error)
 }
 
 type RateProvider interface {
 	Get(ctx context.Context, pair string) (domain.Quote, error)
 }
 
-// IdempotencyStore handles short-lived request deduplication.
+// ProviderHealthStore keeps per-provider call statistics.
+type ProviderHealthStore interface {
+	Record(ctx context.Context, provider string, call domain.ProviderCall) error
+	// List returns every known provider, sorted by name.
+	List(ctx context.Context) ([]domain.ProviderHealth, error)
+}
+
+// IdempotencyStore remembers requests made under an idempotency key so that retries get
+// the original response.
 type IdempotencyStore interface {
-	TryReserve(ctx context.Context, key string) (bool, error)
+	// Reserve claims key for a request with the given fingerprint. When the key is already
+	// taken it returns false and the stored record.
+	Reserve(ctx context.Context, key, fingerprint string) (domain.IdempotencyRecord, bool, error)
+	// Complete stores the outcome of the request that reserved key.
+	Complete(ctx context.Context, key string, rec domain.IdempotencyRecord) error
+	// Release frees the key of a request that failed so that it can be retried.
+	Release(ctx context.Context, key string) error
+}
+
+// RateLimiter counts requests per key in a sliding window shared by all API replicas.
+type RateLimiter interface {
+	// Allow records a request under key unless limit requests were already made within
+	// the last window.
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (domain.RateLimitDecision, error)
 }
@@ -2,7 +2,13 @@ package application
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
 	"time"
 
 	"fxrates-service/internal/domain"
@@ -14,6 +20,12 @@ import (
 type ClockFunc func() time.Time
 type IDGenFunc func() string
 
+// RedactFunc scrubs secrets from error text before it is stored or returned.
+type RedactFunc func(string) string
+
+// WarnFunc reports an error the service recovered from instead of returning it.
+type WarnFunc func(msg string, err error)
+
 // Option allows injecting behavior into FXRatesService
 type Option func(*FXRatesService)
 
@@ -23,14 +35,72 @@ type FXRatesService struct {
 	rateProvider  RateProvider
 	uow           UnitOfWork
 
-	now   ClockFunc
-	newID IDGenFunc
-	idem  IdempotencyStore
+	now    ClockFunc
+	newID  IDGenFunc
+	idem   IdempotencyStore
+	redact RedactFunc
+	warn   WarnFunc
+	// workerID names this process in the attempt history.
+	workerID string
+
+	guard      *QuoteGuard
+	quarantine QuarantineRepo
+	health     ProviderHealthStore
+	batches    UpdateBatchRepo
+	attempts   AttemptRepo
+	notifier   UpdateNotifier
+	feed       QuoteFeed
 }
 
-func WithClock(f ClockFunc) Option { return func(s *FXRatesService) { s.now = f } }
-func WithIDGen(f IDGenFunc) Option { return func(s *FXRatesService) { s.newID = f } }
-func WithUoW(u UnitOfWork) Option  { return func(s *FXRatesService) { s.uow = u } }
+func WithClock(f ClockFunc) Option     { return func(s *FXRatesService) { s.now = f } }
+func WithIDGen(f IDGenFunc) Option     { return func(s *FXRatesService) { s.newID = f } }
+func WithUoW(u UnitOfWork) Option      { return func(s *FXRatesService) { s.uow = u } }
+func WithRedactor(f RedactFunc) Option { return func(s *FXRatesService) { s.redact = f } }
+func WithWarnLog(f WarnFunc) Option    { return func(s *FXRatesService) { s.warn = f } }
+
+// WithWorkerID sets the worker recorded with each update attempt. An empty id keeps the
+// default, host:pid.
+func WithWorkerID(id string) Option {
+	return func(s *FXRatesService) {
+		if id != "" {
+			s.workerID = id
+		}
+	}
+}
+
+// WithProviderHealth exposes provider call statistics through ProviderStatus.
+func WithProviderHealth(h ProviderHealthStore) Option {
+	return func(s *FXRatesService) { s.health = h }
+}
+
+// WithUpdateBatches enables batch update requests.
+func WithUpdateBatches(b UpdateBatchRepo) Option {
+	return func(s *FXRatesService) { s.batches = b }
+}
+
+// WithAttempts records every processing run of an update job.
+func WithAttempts(a AttemptRepo) Option {
+	return func(s *FXRatesService) { s.attempts = a }
+}
+
+// WithUpdateNotifier lets WaitQuoteUpdate wake up on status changes instead of polling only.
+func WithUpdateNotifier(n UpdateNotifier) Option {
+	return func(s *FXRatesService) { s.notifier = n }
+}
+
+// WithQuoteFeed enables WatchQuotes.
+func WithQuoteFeed(f QuoteFeed) Option {
+	return func(s *FXRatesService) { s.feed = f }
+}
+
+// WithQuoteGuard validates fetched quotes before they are stored. Rejected quotes are
+// kept in the quarantine repo when one is given.
+func WithQuoteGuard(g QuoteGuard, quarantine QuarantineRepo) Option {
+	return func(s *FXRatesService) {
+		s.guard = &g
+		s.quarantine = quarantine
+	}
+}
 
 func NewService(quoteRepo QuoteRepo, updateJobRepo UpdateJobRepo, rateProvider RateProvider, idem IdempotencyStore, opts ...Option) *FXRatesService {
 	s := &FXRatesService{
@@ -40,6 +110,9 @@ func NewService(quoteRepo QuoteRepo, updateJobRepo UpdateJobRepo, rateProvider R
 		uow:           NoopUoW{},
 		now:           time.Now,
 		newID:         func() string { return uuid.NewString() },
+		redact:        func(s string) string { return s },
+		warn:          func(string, error) {},
+		workerID:      defaultWorkerID(),
 	}
 	if idem != nil {
 		s.idem = idem
@@ -50,28 +123,100 @@ func NewService(quoteRepo QuoteRepo, updateJobRepo UpdateJobRepo, rateProvider R
 	return s
 }
 
-func (s *FXRatesService) RequestQuoteUpdate(ctx context.Context, pair string, idem *string) (string, error) {
-	if idem == nil || *idem == "" {
-		return "", ErrBadRequest
+// defaultWorkerID identifies the current process as host:pid.
+func defaultWorkerID() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
 	}
-	var ok bool
-	var err error
-	if s.idem != nil {
-		ok, err = s.idem.TryReserve(ctx, *idem)
-	} else {
-		ok = true
+	return host + ":" + strconv.Itoa(os.Getpid())
+}
+
+// RequestQuoteUpdate queues an update for pair and hands its id to respond, which
+// dispatches it and returns the status the caller answers with (respond may be nil).
+// A retry under the same idempotency key returns the original update id and status with
+// replayed set; reusing the key for another pair fails with ErrIdempotencyMismatch, and
+// ErrConflict while the first request is in flight. When respond reports that the update
+// could not be dispatched, the job is canceled and the key released, so that a retry
+// queues and dispatches a new one instead of replaying the failure.
+func (s *FXRatesService) RequestQuoteUpdate(ctx context.Context, pair string, idem *string, respond func(id string) (int, error)) (id string, status int, replayed bool, err error) {
+	if idem == nil || *idem == "" {
+		return "", 0, false, ErrBadRequest
 	}
+	fp := requestFingerprint("quote_update", pair)
+	prev, err := s.reserveIdem(ctx, *idem, fp)
 	if err != nil {
-		return "", err
+		return "", 0, false, err
 	}
-	if !ok {
-		return "", ErrConflict
+	if prev != nil {
+		return prev.UpdateID, prev.Status, true, nil
 	}
 	updateID, err := s.updateJobRepo.CreateQueued(ctx, pair, idem)
 	if err != nil {
-		return "", err
+		s.releaseIdem(ctx, *idem)
+		return "", 0, false, err
+	}
+	if respond != nil {
+		var dispatchErr error
+		if status, dispatchErr = respond(updateID); dispatchErr != nil {
+			if _, err := s.updateJobRepo.CancelQueued(context.WithoutCancel(ctx), updateID); err != nil {
+				s.warn("quote_update.cancel_failed", err)
+			}
+			s.releaseIdem(ctx, *idem)
+			return updateID, status, false, nil
+		}
 	}
-	return updateID, nil
+	s.completeIdem(ctx, *idem, domain.IdempotencyRecord{Fingerprint: fp, UpdateID: updateID, Status: status})
+	return updateID, status, false, nil
+}
+
+// reserveIdem claims key for a request with fingerprint fp. A non-nil record is the
+// completed original request, whose response should be replayed.
+func (s *FXRatesService) reserveIdem(ctx context.Context, key, fp string) (*domain.IdempotencyRecord, error) {
+	if s.idem == nil {
+		return nil, nil
+	}
+	rec, ok, err := s.idem.Reserve(ctx, key, fp)
+	if err != nil || ok {
+		return nil, err
+	}
+	switch {
+	// Keys stored before fingerprints existed carry none; treat them as in flight.
+	case rec.Fingerprint != "" && rec.Fingerprint != fp:
+		return nil, ErrIdempotencyMismatch
+	case !rec.Completed():
+		return nil, ErrConflict
+	}
+	return &rec, nil
+}
+
+// completeIdem stores the response of the request that reserved key. The work is done
+// by then, so a failure is only reported: retries see the key in flight until it expires.
+func (s *FXRatesService) completeIdem(ctx context.Context, key string, rec domain.IdempotencyRecord) {
+	if s.idem == nil {
+		return
+	}
+	if err := s.idem.Complete(context.WithoutCancel(ctx), key, rec); err != nil {
+		s.warn("idempotency.complete_failed", err)
+	}
+}
+
+// releaseIdem frees key after the request failed. It runs even when the client is gone;
+// a key that cannot be released only blocks retries until it expires.
+func (s *FXRatesService) releaseIdem(ctx context.Context, key string) {
+	if s.idem != nil {
+		_ = s.idem.Release(context.WithoutCancel(ctx), key)
+	}
+}
+
+// requestFingerprint identifies what a request made under an idempotency key asked for.
+func requestFingerprint(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 func (s *FXRatesService) GetQuoteUpdate(ctx context.Context, id string) (domain.QuoteUpdate, error) {
@@ -82,9 +227,295 @@ func (s *FXRatesService) GetQuoteUpdate(ctx context.Context, id string) (domain.
 		}
 		return domain.QuoteUpdate{}, err
 	}
+	// Rows written before redaction existed may still hold secrets.
+	if upd.Error != nil {
+		msg := s.redact(*upd.Error)
+		upd.Error = &msg
+	}
+	if s.attempts != nil {
+		if upd.Attempts, err = s.attempts.List(ctx, id); err != nil {
+			return domain.QuoteUpdate{}, err
+		}
+		if upd.Attempts == nil {
+			upd.Attempts = []domain.QuoteUpdateAttempt{}
+		}
+		for i, a := range upd.Attempts {
+			if a.Error != nil {
+				msg := s.redact(*a.Error)
+				upd.Attempts[i].Error = &msg
+			}
+		}
+	}
 	return upd, nil
 }
 
+// MaxQuoteUpdateWait caps the wait accepted by WaitQuoteUpdate.
+const MaxQuoteUpdateWait = 30 * time.Second
+
+// waitRecheckInterval bounds how long WaitQuoteUpdate trusts the notifier before it
+// reads the job again; it is the only wake-up source when no notifier is configured.
+const waitRecheckInterval = time.Second
+
+// WaitQuoteUpdate returns the update once it reaches a terminal status or wait elapses,
+// whichever comes first. A timed-out wait is not an error; the caller inspects Status.
+// wait is capped at MaxQuoteUpdateWait.
+func (s *FXRatesService) WaitQuoteUpdate(ctx context.Context, id string, wait time.Duration) (domain.QuoteUpdate, error) {
+	wait = min(wait, MaxQuoteUpdateWait)
+	var changed <-chan struct{}
+	if s.notifier != nil && wait > 0 {
+		// Subscribe before the first read so a change in between is not lost.
+		ch, release := s.notifier.Subscribe(id)
+		defer release()
+		changed = ch
+	}
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+	recheck := time.NewTicker(waitRecheckInterval)
+	defer recheck.Stop()
+	for {
+		upd, err := s.GetQuoteUpdate(ctx, id)
+		if err != nil || upd.Status.Terminal() || wait <= 0 {
+			return upd, err
+		}
+		select {
+		case <-ctx.Done():
+			return domain.QuoteUpdate{}, ctx.Err()
+		case <-deadline.C:
+			return s.GetQuoteUpdate(ctx, id)
+		case <-changed:
+		case <-recheck.C:
+		}
+	}
+}
+
+// WatchQuoteUpdate emits the current state of update id and then each status change.
+// The channel is closed after a terminal status, when ctx ends, or when a re-read fails.
+func (s *FXRatesService) WatchQuoteUpdate(ctx context.Context, id string) (<-chan domain.QuoteUpdate, error) {
+	var changed <-chan struct{}
+	release := func() {}
+	if s.notifier != nil {
+		// Subscribe before the first read so a change in between is not lost.
+		changed, release = s.notifier.Subscribe(id)
+	}
+	upd, err := s.GetQuoteUpdate(ctx, id)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	out := make(chan domain.QuoteUpdate)
+	go func() {
+		defer close(out)
+		defer release()
+		recheck := time.NewTicker(waitRecheckInterval)
+		defer recheck.Stop()
+		var last domain.QuoteUpdateStatus
+		for {
+			if upd.Status != last {
+				select {
+				case out <- upd:
+					last = upd.Status
+				case <-ctx.Done():
+					return
+				}
+			}
+			if upd.Status.Terminal() {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-changed:
+			case <-recheck.C:
+			}
+			if upd, err = s.GetQuoteUpdate(ctx, id); err != nil {
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// ListQuoteUpdates returns one page of update jobs, newest first, plus per-status counts
+// for the filter. f.Limit must be positive.
+func (s *FXRatesService) ListQuoteUpdates(ctx context.Context, f domain.QuoteUpdateFilter) (domain.QuoteUpdatePage, error) {
+	if f.Limit <= 0 {
+		return domain.QuoteUpdatePage{}, ErrBadRequest
+	}
+	// Fetch one extra row to learn whether another page follows.
+	probe := f
+	probe.Limit = f.Limit + 1
+	items, err := s.updateJobRepo.List(ctx, probe)
+	if err != nil {
+		return domain.QuoteUpdatePage{}, err
+	}
+	counts, err := s.updateJobRepo.CountByStatus(ctx, f)
+	if err != nil {
+		return domain.QuoteUpdatePage{}, err
+	}
+	page := domain.QuoteUpdatePage{Items: items, Counts: counts}
+	if len(items) > f.Limit {
+		page.Items = items[:f.Limit]
+		last := page.Items[f.Limit-1]
+		page.Next = &domain.QuoteUpdateCursor{RequestedAt: last.RequestedAt, ID: last.ID}
+	}
+	for i, u := range page.Items {
+		if u.Error != nil {
+			msg := s.redact(*u.Error)
+			page.Items[i].Error = &msg
+		}
+	}
+	return page, nil
+}
+
+// CancelQuoteUpdate withdraws a queued update. Returns ErrConflict once processing has
+// started or the job has finished.
+func (s *FXRatesService) CancelQuoteUpdate(ctx context.Context, id string) (domain.QuoteUpdate, error) {
+	ok, err := s.updateJobRepo.CancelQueued(ctx, id)
+	if err != nil {
+		return domain.QuoteUpdate{}, err
+	}
+	if !ok {
+		return domain.QuoteUpdate{}, ErrConflict
+	}
+	return s.GetQuoteUpdate(ctx, id)
+}
+
+// RetryQuoteUpdate puts a failed update back in the queue under its original id and
+// hands it to dispatch (which may be nil). It returns ErrConflict when the job has not
+// failed. When dispatch fails the job is marked failed again, so that it can be retried
+// later, and ErrDispatchUnavailable is returned.
+func (s *FXRatesService) RetryQuoteUpdate(ctx context.Context, id string, dispatch func(domain.QuoteUpdate) error) (domain.QuoteUpdate, error) {
+	ok, err := s.updateJobRepo.RequeueFailed(ctx, id)
+	if err != nil {
+		return domain.QuoteUpdate{}, err
+	}
+	if !ok {
+		return domain.QuoteUpdate{}, ErrConflict
+	}
+	upd, err := s.GetQuoteUpdate(ctx, id)
+	if err != nil || dispatch == nil {
+		return upd, err
+	}
+	if err := dispatch(upd); err != nil {
+		msg := s.redact("dispatch failed: " + err.Error())
+		if uerr := s.updateJobRepo.UpdateStatus(context.WithoutCancel(ctx), id, domain.QuoteUpdateStatusFailed, &msg); uerr != nil {
+			s.warn("quote_update.refail_failed", uerr)
+		}
+		return upd, fmt.Errorf("%w: %v", ErrDispatchUnavailable, err)
+	}
+	return upd, nil
+}
+
+// StartQuoteUpdate claims a dispatched update for processing. It reports false when the
+// job is no longer queued, e.g. because it was canceled, and must then be skipped.
+func (s *FXRatesService) StartQuoteUpdate(ctx context.Context, id string) (bool, error) {
+	return s.updateJobRepo.ClaimByID(ctx, id)
+}
+
+// maxBatchPairs caps the number of update jobs a single batch request may create.
+const maxBatchPairs = 50
+
+// RequestQuoteUpdateBatch queues one update per distinct pair under a single idempotency
+// key. The batch and its jobs are created in one unit of work, then handed to respond as
+// in RequestQuoteUpdate. A retry with the same set of pairs returns the batch's current
+// state and the original status with replayed set. When respond reports a dispatch
+// failure, the batch's still-queued updates are canceled and the key released.
+func (s *FXRatesService) RequestQuoteUpdateBatch(ctx context.Context, pairs []string, idem *string, respond func(domain.QuoteUpdateBatch) (int, error)) (domain.QuoteUpdateBatch, int, bool, error) {
+	if idem == nil || *idem == "" || len(pairs) == 0 {
+		return domain.QuoteUpdateBatch{}, 0, false, ErrBadRequest
+	}
+	if s.batches == nil {
+		return domain.QuoteUpdateBatch{}, 0, false, errors.New("update batches are not configured")
+	}
+	seen := make(map[string]bool, len(pairs))
+	var uniq []string
+	for _, p := range pairs {
+		if !seen[p] {
+			seen[p] = true
+			uniq = append(uniq, p)
+		}
+	}
+	if len(uniq) > maxBatchPairs {
+		return domain.QuoteUpdateBatch{}, 0, false, ErrBadRequest
+	}
+	// Batch keys live in their own namespace so they never collide with single requests.
+	key := "batch:" + *idem
+	// The same pairs in another order or with repeats are the same request.
+	fp := requestFingerprint(append([]string{"quote_update_batch"}, slices.Sorted(slices.Values(uniq))...)...)
+	prev, err := s.reserveIdem(ctx, key, fp)
+	if err != nil {
+		return domain.QuoteUpdateBatch{}, 0, false, err
+	}
+	if prev != nil {
+		b, err := s.GetQuoteUpdateBatch(ctx, prev.BatchID)
+		if err != nil {
+			return domain.QuoteUpdateBatch{}, 0, false, err
+		}
+		// Members come back by pair; answer in request order like the original did.
+		slices.SortStableFunc(b.Updates, func(x, y domain.QuoteUpdate) int {
+			return slices.Index(uniq, string(x.Pair)) - slices.Index(uniq, string(y.Pair))
+		})
+		return b, prev.Status, true, nil
+	}
+	now := s.now().UTC()
+	batch := domain.QuoteUpdateBatch{CreatedAt: now}
+	err = s.uow.Do(ctx, func(txCtx context.Context) error {
+		id, err := s.batches.Create(txCtx)
+		if err != nil {
+			return err
+		}
+		batch.ID = id
+		for _, p := range uniq {
+			uid, err := s.batches.AddQueued(txCtx, id, p)
+			if err != nil {
+				return err
+			}
+			batch.Updates = append(batch.Updates, domain.QuoteUpdate{
+				ID: uid, Pair: domain.Pair(p), Status: domain.QuoteUpdateStatusQueued, UpdatedAt: now,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		s.releaseIdem(ctx, key)
+		return domain.QuoteUpdateBatch{}, 0, false, err
+	}
+	var status int
+	if respond != nil {
+		var dispatchErr error
+		if status, dispatchErr = respond(batch); dispatchErr != nil {
+			// Withdraw what no worker has claimed yet; a retry queues a whole new batch.
+			for _, u := range batch.Updates {
+				if _, err := s.updateJobRepo.CancelQueued(context.WithoutCancel(ctx), u.ID); err != nil {
+					s.warn("quote_update.cancel_failed", err)
+				}
+			}
+			s.releaseIdem(ctx, key)
+			return batch, status, false, nil
+		}
+	}
+	s.completeIdem(ctx, key, domain.IdempotencyRecord{Fingerprint: fp, BatchID: batch.ID, Status: status})
+	return batch, status, false, nil
+}
+
+// GetQuoteUpdateBatch returns a batch with the current state of its member updates.
+func (s *FXRatesService) GetQuoteUpdateBatch(ctx context.Context, id string) (domain.QuoteUpdateBatch, error) {
+	if s.batches == nil {
+		return domain.QuoteUpdateBatch{}, domain.ErrNotFound
+	}
+	b, err := s.batches.Get(ctx, id)
+	if err != nil {
+		return domain.QuoteUpdateBatch{}, err
+	}
+	for i, u := range b.Updates {
+		if u.Error != nil {
+			msg := s.redact(*u.Error)
+			b.Updates[i].Error = &msg
+		}
+	}
+	return b, nil
+}
+
 func (s *FXRatesService) GetLastQuote(ctx context.Context, pair string) (domain.Quote, error) {
 	q, err := s.quoteRepo.GetLast(ctx, pair)
 	if err != nil {
@@ -96,6 +527,122 @@ func (s *FXRatesService) GetLastQuote(ctx context.Context, pair string) (domain.
 	return q, nil
 }
 
+// GetLatestQuotes returns the last quotes of pairs, in request order, and the pairs that
+// have none. Duplicates are ignored; no pairs means all supported pairs.
+func (s *FXRatesService) GetLatestQuotes(ctx context.Context, pairs []string) ([]domain.Quote, []string, error) {
+	if len(pairs) == 0 {
+		pairs = domain.SupportedPairs()
+	}
+	seen := make(map[string]bool, len(pairs))
+	uniq := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		if !seen[p] {
+			seen[p] = true
+			uniq = append(uniq, p)
+		}
+	}
+	found, err := s.quoteRepo.GetMany(ctx, uniq)
+	if err != nil {
+		return nil, nil, err
+	}
+	byPair := make(map[string]domain.Quote, len(found))
+	for _, q := range found {
+		byPair[string(q.Pair)] = q
+	}
+	quotes := make([]domain.Quote, 0, len(found))
+	var missing []string
+	for _, p := range uniq {
+		if q, ok := byPair[p]; ok {
+			quotes = append(quotes, q)
+		} else {
+			missing = append(missing, p)
+		}
+	}
+	return quotes, missing, nil
+}
+
+// maxQuoteReplay bounds how many missed changes WatchQuotes replays on resume.
+const maxQuoteReplay = 1000
+
+// ErrQuoteFeedUnavailable is returned by WatchQuotes when no quote feed is configured.
+var ErrQuoteFeedUnavailable = errors.New("quote feed is not configured")
+
+// WatchQuotes streams stored quote changes for pairs (all pairs when empty) until ctx is
+// done. With after set, changes recorded after that history id are replayed first. The
+// channel is closed when the stream ends: on ctx, when the subscriber fell behind, or after
+// a replay cut short at maxQuoteReplay. The caller then resumes from the last id it got.
+func (s *FXRatesService) WatchQuotes(ctx context.Context, pairs []string, after *int64) (<-chan domain.QuoteHistory, error) {
+	if s.feed == nil {
+		return nil, ErrQuoteFeedUnavailable
+	}
+	// Subscribe before reading the backlog so nothing committed in between is lost.
+	live, release := s.feed.Subscribe()
+	var backlog []domain.QuoteHistory
+	if after != nil {
+		var err error
+		if backlog, err = s.quoteRepo.HistorySince(ctx, *after, pairs, maxQuoteReplay); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	want := make(map[domain.Pair]bool, len(pairs))
+	for _, p := range pairs {
+		want[domain.Pair(p)] = true
+	}
+	out := make(chan domain.QuoteHistory)
+	go func() {
+		defer close(out)
+		defer release()
+		send := func(h domain.QuoteHistory) bool {
+			if len(want) > 0 && !want[h.Pair] {
+				return true
+			}
+			select {
+			case out <- h:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		// Live changes may repeat the backlog. They are deduplicated by id rather than by
+		// comparing against the highest id sent: ids are assigned at insert but notified in
+		// commit order, so a lower id can legitimately arrive after a higher one.
+		replayed := make(map[int64]bool, len(backlog))
+		for _, h := range backlog {
+			if !send(h) {
+				return
+			}
+			replayed[h.ID] = true
+		}
+		if len(backlog) == maxQuoteReplay {
+			// More is missing than one replay carries; going live now would skip it.
+			return
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case h, ok := <-live:
+				if !ok {
+					return
+				}
+				if replayed[h.ID] {
+					continue
+				}
+				if !send(h) {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+// UpdateWaiter is a small facade for blocking reads of an update job.
+type UpdateWaiter interface {
+	WaitQuoteUpdate(ctx context.Context, id string, wait time.Duration) (domain.QuoteUpdate, error)
+}
+
 // QuoteFetcher is a small facade to fetch quotes via the service without exposing ports.
 type QuoteFetcher interface {
 	FetchQuote(ctx context.Context, pair string) (domain.Quote, error)
@@ -113,10 +660,45 @@ func (s *FXRatesService) CompleteQuoteUpdate(
 	updateID string,
 	fetch func(context.Context) (domain.Quote, error),
 	source string,
-) error {
+) (err error) {
+	// Provider calls carry a trace ID; the update ID stands in when the caller had none.
+	if TraceIDFromContext(ctx) == "" {
+		ctx = ContextWithTraceID(ctx, updateID)
+	}
+	if s.attempts != nil {
+		n, aerr := s.attempts.Start(ctx, updateID, s.workerID, s.now())
+		if aerr == nil {
+			defer func() {
+				r := recover()
+				var msg *string
+				switch {
+				case r != nil:
+					m := s.redact(fmt.Sprintf("panic: %v", r))
+					msg = &m
+				case err != nil:
+					m := s.redact(err.Error())
+					msg = &m
+				}
+				// The attempt row is history only; failing to close it must not fail the job,
+				// and it is closed even when the fetch ran out of time.
+				_ = s.attempts.Finish(context.WithoutCancel(ctx), updateID, n, s.now(), msg)
+				if r != nil {
+					panic(r)
+				}
+			}()
+		}
+	}
 	q, err := fetch(ctx)
 	if err != nil {
-		msg := err.Error()
+		msg := s.redact(err.Error())
+		_ = s.updateJobRepo.UpdateStatus(ctx, updateID, domain.QuoteUpdateStatusFailed, &msg)
+		return err
+	}
+	if q.Cached {
+		source += ":cache"
+	}
+	if err := s.screenQuote(ctx, updateID, q, source); err != nil {
+		msg := s.redact(err.Error())
 		_ = s.updateJobRepo.UpdateStatus(ctx, updateID, domain.QuoteUpdateStatusFailed, &msg)
 		return err
 	}
@@ -141,6 +723,123 @@ func (s *FXRatesService) CompleteQuoteUpdate(
 	})
 }
 
+// FailQuoteUpdate marks an update as failed without recording a processing attempt.
+// Workers use it when a run ended abnormally, e.g. in a panic.
+func (s *FXRatesService) FailQuoteUpdate(ctx context.Context, updateID string, cause error) error {
+	msg := s.redact(cause.Error())
+	return s.updateJobRepo.UpdateStatus(ctx, updateID, domain.QuoteUpdateStatusFailed, &msg)
+}
+
+// screenQuote runs the quote guard, if any, and quarantines a rejected quote.
+func (s *FXRatesService) screenQuote(ctx context.Context, updateID string, q domain.Quote, source string) error {
+	if s.guard == nil {
+		return nil
+	}
+	var last *domain.Quote
+	prev, err := s.quoteRepo.GetLast(ctx, string(q.Pair))
+	switch {
+	case err == nil:
+		last = &prev
+	case !errors.Is(err, domain.ErrNotFound):
+		return err
+	}
+	rej := s.guard.Check(q, last)
+	if rej == nil {
+		return nil
+	}
+	if s.quarantine != nil {
+		if _, err := s.quarantine.Add(ctx, domain.QuarantinedQuote{
+			Pair:     q.Pair,
+			Price:    q.Price,
+			QuotedAt: q.UpdatedAt,
+			Source:   source,
+			UpdateID: &updateID,
+			Reason:   rej.Reason,
+			Detail:   rej.Detail,
+		}); err != nil {
+			return fmt.Errorf("%w (quarantine failed: %v)", rej, err)
+		}
+	}
+	return rej
+}
+
+// ListQuarantinedQuotes returns quotes awaiting release, newest first.
+func (s *FXRatesService) ListQuarantinedQuotes(ctx context.Context, pair string, limit int) ([]domain.QuarantinedQuote, error) {
+	if s.quarantine == nil {
+		return nil, nil
+	}
+	return s.quarantine.ListPending(ctx, pair, limit)
+}
+
+// ReleaseQuarantinedQuote accepts a quarantined quote: it is appended to history, becomes the
+// latest quote unless a newer one is already stored, and its update job is marked done.
+// Returns ErrConflict if the quote was already released.
+func (s *FXRatesService) ReleaseQuarantinedQuote(ctx context.Context, id int64) (domain.QuarantinedQuote, error) {
+	if s.quarantine == nil {
+		return domain.QuarantinedQuote{}, domain.ErrNotFound
+	}
+	qq, err := s.quarantine.Get(ctx, id)
+	if err != nil {
+		return domain.QuarantinedQuote{}, err
+	}
+	if qq.ReleasedAt != nil {
+		return domain.QuarantinedQuote{}, ErrConflict
+	}
+	now := s.now().UTC()
+	err = s.uow.Do(ctx, func(txCtx context.Context) error {
+		ok, err := s.quarantine.MarkReleased(txCtx, id, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrConflict
+		}
+		if err := s.quoteRepo.AppendHistory(txCtx, domain.QuoteHistory{
+			Pair:     qq.Pair,
+			Price:    qq.Price,
+			QuotedAt: qq.QuotedAt,
+			Source:   qq.Source + ":released",
+			UpdateID: qq.UpdateID,
+		}); err != nil {
+			return err
+		}
+		last, err := s.quoteRepo.GetLast(txCtx, string(qq.Pair))
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return err
+		}
+		if err != nil || !qq.QuotedAt.Before(last.UpdatedAt) {
+			if err := s.quoteRepo.Upsert(txCtx, domain.Quote{
+				Pair:      qq.Pair,
+				Price:     qq.Price,
+				UpdatedAt: qq.QuotedAt,
+			}); err != nil {
+				return err
+			}
+		}
+		if qq.UpdateID == nil {
+			return nil
+		}
+		err = s.updateJobRepo.UpdateStatus(txCtx, *qq.UpdateID, domain.QuoteUpdateStatusDone, nil)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return domain.QuarantinedQuote{}, err
+	}
+	qq.ReleasedAt = &now
+	return qq, nil
+}
+
+// ProviderStatus returns health statistics for the configured upstream providers.
+func (s *FXRatesService) ProviderStatus(ctx context.Context) ([]domain.ProviderHealth, error) {
+	if s.health == nil {
+		return nil, nil
+	}
+	return s.health.List(ctx)
+}
+
 // ProcessQueueBatch claims queued jobs and processes them using the service's RateProvider.
 // Best-effort: errors are aggregated and returned as a single error if any occurred.
 func (s *FXRatesService) ProcessQueueBatch(
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RequestQuoteUpdateBatch(t *testing.T) {
+	t.Parallel()
+	jobs := &fakeUpdateJobRepo{}
+	svc := NewService(&fakeQuoteRepo{}, jobs, &fakeRateProvider{}, &fakeIdem{},
+		WithUpdateBatches(&fakeUpdateBatchRepo{jobs: jobs}))
+	ctx := context.Background()
+	key := "basket-1"
+
+	b, status, replayed, err := svc.RequestQuoteUpdateBatch(ctx, []string{"EUR/USD", "USD/MXN", "EUR/USD"}, &key, func(domain.QuoteUpdateBatch) (int, error) { return 202, nil })
+	require.NoError(t, err)
+	require.False(t, replayed)
+	require.Equal(t, 202, status)
+	require.Len(t, b.Updates, 2)
+
+	again, status, replayed, err := svc.RequestQuoteUpdateBatch(ctx, []string{"USD/MXN", "EUR/USD"}, &key, nil)
+	require.NoError(t, err)
+	require.True(t, replayed)
+	require.Equal(t, 202, status)
+	require.Equal(t, b.ID, again.ID)
+
+	_, _, _, err = svc.RequestQuoteUpdateBatch(ctx, []string{"EUR/USD"}, &key, nil)
+	require.ErrorIs(t, err, ErrIdempotencyMismatch)
+
+	got, err := svc.GetQuoteUpdateBatch(ctx, b.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateBatchPending, got.Status())
+
+	msg := "boom"
+	require.NoError(t, jobs.UpdateStatus(ctx, b.Updates[0].ID, domain.QuoteUpdateStatusDone, nil))
+	require.NoError(t, jobs.UpdateStatus(ctx, b.Updates[1].ID, domain.QuoteUpdateStatusFailed, &msg))
+	got, err = svc.GetQuoteUpdateBatch(ctx, b.ID)
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateBatchPartial, got.Status())
+}
+
+func Test_RequestQuoteUpdateBatch_Validation(t *testing.T) {
+	t.Parallel()
+	jobs := &fakeUpdateJobRepo{}
+	svc := NewService(&fakeQuoteRepo{}, jobs, &fakeRateProvider{}, nil,
+		WithUpdateBatches(&fakeUpdateBatchRepo{jobs: jobs}))
+	key := "k"
+
+	_, _, _, err := svc.RequestQuoteUpdateBatch(context.Background(), nil, &key, nil)
+	require.ErrorIs(t, err, ErrBadRequest)
+	_, _, _, err = svc.RequestQuoteUpdateBatch(context.Background(), []string{"EUR/USD"}, nil, nil)
+	require.ErrorIs(t, err, ErrBadRequest)
+
+	_, err = svc.GetQuoteUpdateBatch(context.Background(), "missing")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
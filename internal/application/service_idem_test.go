@@ -2,31 +2,144 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fxrates-service/internal/domain"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
-type fakeIdem struct{ seen map[string]bool }
+type fakeIdem struct {
+	recs map[string]domain.IdempotencyRecord
+}
 
-func (f *fakeIdem) TryReserve(_ context.Context, k string) (bool, error) {
-	if f.seen == nil {
-		f.seen = map[string]bool{}
+func (f *fakeIdem) Reserve(_ context.Context, k, fp string) (domain.IdempotencyRecord, bool, error) {
+	if f.recs == nil {
+		f.recs = map[string]domain.IdempotencyRecord{}
 	}
-	if f.seen[k] {
-		return false, nil
+	if rec, ok := f.recs[k]; ok {
+		return rec, false, nil
 	}
-	f.seen[k] = true
-	return true, nil
+	f.recs[k] = domain.IdempotencyRecord{Fingerprint: fp}
+	return domain.IdempotencyRecord{}, true, nil
 }
 
-func TestRequestQuoteUpdate_Idempotency_Conflict(treated *testing.T) {
+func (f *fakeIdem) Complete(_ context.Context, k string, rec domain.IdempotencyRecord) error {
+	f.recs[k] = rec
+	return nil
+}
+
+func (f *fakeIdem) Release(_ context.Context, k string) error {
+	delete(f.recs, k)
+	return nil
+}
+
+func TestRequestQuoteUpdate_Idempotency_Replay(t *testing.T) {
 	idem := &fakeIdem{}
 	svc := NewService(nil, &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}, nil, idem)
+	ctx := context.Background()
 	key := "ik-1"
-	if _, err := svc.RequestQuoteUpdate(context.Background(), "EUR/USD", &key); err != nil {
-		treated.Fatalf("unexpected err first call: %v", err)
+
+	id, status, replayed, err := svc.RequestQuoteUpdate(ctx, "EUR/USD", &key, func(string) (int, error) { return 202, nil })
+	require.NoError(t, err)
+	require.False(t, replayed)
+	require.Equal(t, 202, status)
+
+	again, status, replayed, err := svc.RequestQuoteUpdate(ctx, "EUR/USD", &key, func(string) (int, error) {
+		t.Fatal("a replay must not respond again")
+		return 0, nil
+	})
+	require.NoError(t, err)
+	require.True(t, replayed)
+	require.Equal(t, id, again)
+	require.Equal(t, 202, status, "a replay answers with the original status")
+
+	_, _, _, err = svc.RequestQuoteUpdate(ctx, "USD/MXN", &key, nil)
+	require.ErrorIs(t, err, ErrIdempotencyMismatch)
+}
+
+func TestRequestQuoteUpdate_Idempotency_DispatchFailureIsNotStored(t *testing.T) {
+	idem := &fakeIdem{}
+	jobs := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}
+	svc := NewService(nil, jobs, nil, idem)
+	ctx := context.Background()
+	key := "ik-1"
+
+	id, status, replayed, err := svc.RequestQuoteUpdate(ctx, "EUR/USD", &key, func(string) (int, error) {
+		return 503, errors.New("queue full")
+	})
+	require.NoError(t, err)
+	require.False(t, replayed)
+	require.Equal(t, 503, status)
+	require.Equal(t, domain.QuoteUpdateStatusCanceled, jobs.jobs[id].Status, "nothing will run the undispatched job")
+	require.NotContains(t, idem.recs, key, "the failure must not be replayed")
+
+	responded := false
+	_, status, replayed, err = svc.RequestQuoteUpdate(ctx, "EUR/USD", &key, func(string) (int, error) {
+		responded = true
+		return 202, nil
+	})
+	require.NoError(t, err)
+	require.False(t, replayed)
+	require.True(t, responded, "the retry dispatches again")
+	require.Equal(t, 202, status)
+}
+
+func TestRequestQuoteUpdate_Idempotency_InFlight(t *testing.T) {
+	idem := &fakeIdem{recs: map[string]domain.IdempotencyRecord{
+		"pending": {Fingerprint: requestFingerprint("quote_update", "EUR/USD")},
+		// Written before records carried fingerprints.
+		"legacy": {},
+	}}
+	svc := NewService(nil, &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}, nil, idem)
+
+	for _, key := range []string{"pending", "legacy"} {
+		_, _, _, err := svc.RequestQuoteUpdate(context.Background(), "EUR/USD", &key, nil)
+		require.ErrorIs(t, err, ErrConflict, key)
 	}
-	if _, err := svc.RequestQuoteUpdate(context.Background(), "EUR/USD", &key); err == nil {
-		treated.Fatalf("expected conflict on duplicate key")
+}
+
+type failingJobRepo struct{ fakeUpdateJobRepo }
+
+func (failingJobRepo) CreateQueued(context.Context, string, *string) (string, error) {
+	return "", errors.New("db down")
+}
+
+func TestRequestQuoteUpdate_Idempotency_ReleasedOnFailure(t *testing.T) {
+	idem := &fakeIdem{}
+	svc := NewService(nil, &failingJobRepo{}, nil, idem)
+	key := "ik-1"
+
+	_, _, _, err := svc.RequestQuoteUpdate(context.Background(), "EUR/USD", &key, nil)
+	require.Error(t, err)
+	require.NotContains(t, idem.recs, key, "a failed request must not block its retry")
+}
+
+type completeFailingIdem struct{ fakeIdem }
+
+func (f *completeFailingIdem) Complete(ctx context.Context, _ string, _ domain.IdempotencyRecord) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
+	return errors.New("redis down")
+}
+
+func TestRequestQuoteUpdate_Idempotency_CompleteFailureIsNotFatal(t *testing.T) {
+	var warned []error
+	idem := &completeFailingIdem{}
+	svc := NewService(nil, &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}, nil, idem,
+		WithWarnLog(func(_ string, err error) { warned = append(warned, err) }))
+	ctx, cancel := context.WithCancel(context.Background())
+	key := "ik-1"
+
+	// The client goes away while the update is being dispatched.
+	id, status, _, err := svc.RequestQuoteUpdate(ctx, "EUR/USD", &key, func(string) (int, error) {
+		cancel()
+		return 202, nil
+	})
+	require.NoError(t, err, "the update is queued; losing the record only delays retries")
+	require.NotEmpty(t, id)
+	require.Equal(t, 202, status)
+	require.Len(t, warned, 1)
+	require.EqualError(t, warned[0], "redis down", "Complete must not see the request's cancellation")
 }
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -22,7 +23,7 @@ func Test_RequestQuoteUpdate(t *testing.T) {
 		WithIDGen(func() string { return "update-1" }),
 	)
 
-	id, err := svc.RequestQuoteUpdate(context.Background(), "EUR/USD", strPtr("idem-1"))
+	id, _, _, err := svc.RequestQuoteUpdate(context.Background(), "EUR/USD", strPtr("idem-1"), nil)
 	require.NoError(t, err)
 	require.Equal(t, "update-1", id)
 	require.Contains(t, u.jobs, "update-1")
@@ -38,7 +39,7 @@ func Test_RequestQuoteUpdate_UnsupportedPair(t *testing.T) {
 		nil,
 	)
 
-	id, err := svc.RequestQuoteUpdate(context.Background(), "GBP/USD", strPtr("idem-1"))
+	id, _, _, err := svc.RequestQuoteUpdate(context.Background(), "GBP/USD", strPtr("idem-1"), nil)
 	require.NoError(t, err)
 	require.NotEmpty(t, id)
 }
@@ -92,4 +93,239 @@ func Test_GetLastQuote_UnsupportedPair(t *testing.T) {
 	require.ErrorIs(t, err, domain.ErrNotFound)
 }
 
+func Test_GetLatestQuotes(t *testing.T) {
+	t.Parallel()
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	qr := &fakeQuoteRepo{store: map[string]domain.Quote{
+		"EUR/USD": {Pair: "EUR/USD", Price: 1.1, UpdatedAt: ts},
+		"USD/MXN": {Pair: "USD/MXN", Price: 17.2, UpdatedAt: ts},
+	}}
+	svc := NewService(qr, &fakeUpdateJobRepo{}, &fakeRateProvider{}, nil)
+
+	quotes, missing, err := svc.GetLatestQuotes(context.Background(), []string{"USD/MXN", "EUR/MXN", "USD/MXN", "EUR/USD"})
+	require.NoError(t, err)
+	require.Len(t, quotes, 2)
+	require.Equal(t, domain.Pair("USD/MXN"), quotes[0].Pair)
+	require.Equal(t, domain.Pair("EUR/USD"), quotes[1].Pair)
+	require.Equal(t, []string{"EUR/MXN"}, missing)
+
+	quotes, missing, err = svc.GetLatestQuotes(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, quotes, 2)
+	require.Len(t, missing, len(domain.SupportedPairs())-2)
+}
+
+func Test_ListQuoteUpdates_Pages(t *testing.T) {
+	t.Parallel()
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	jobs := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"a": {ID: "a", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusDone, RequestedAt: base},
+		"b": {ID: "b", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusQueued, RequestedAt: base.Add(time.Minute)},
+		"c": {ID: "c", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusQueued, RequestedAt: base.Add(2 * time.Minute)},
+	}}
+	svc := NewService(&fakeQuoteRepo{}, jobs, &fakeRateProvider{}, nil)
+
+	page, err := svc.ListQuoteUpdates(context.Background(), domain.QuoteUpdateFilter{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	require.Equal(t, "c", page.Items[0].ID)
+	require.NotNil(t, page.Next)
+	require.Equal(t, int64(2), page.Counts[domain.QuoteUpdateStatusQueued])
+
+	page, err = svc.ListQuoteUpdates(context.Background(), domain.QuoteUpdateFilter{Limit: 2, After: page.Next})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+	require.Equal(t, "a", page.Items[0].ID)
+	require.Nil(t, page.Next)
+
+	_, err = svc.ListQuoteUpdates(context.Background(), domain.QuoteUpdateFilter{})
+	require.ErrorIs(t, err, ErrBadRequest)
+}
+
+func Test_CancelQuoteUpdate(t *testing.T) {
+	t.Parallel()
+	jobs := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"u1": {ID: "u1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusQueued},
+	}}
+	svc := NewService(&fakeQuoteRepo{}, jobs, &fakeRateProvider{}, nil)
+	ctx := context.Background()
+
+	upd, err := svc.CancelQuoteUpdate(ctx, "u1")
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateStatusCanceled, upd.Status)
+
+	ok, err := svc.StartQuoteUpdate(ctx, "u1")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = svc.CancelQuoteUpdate(ctx, "u1")
+	require.ErrorIs(t, err, ErrConflict)
+	_, err = svc.CancelQuoteUpdate(ctx, "missing")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
+func Test_RetryQuoteUpdate_RecordsAttempts(t *testing.T) {
+	t.Parallel()
+	jobs := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"u1": {ID: "u1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusProcessing},
+	}}
+	svc := NewService(&fakeQuoteRepo{store: map[string]domain.Quote{}}, jobs, &fakeRateProvider{}, nil,
+		WithAttempts(&fakeAttemptRepo{}), WithWorkerID("worker-a"))
+	ctx := context.Background()
+
+	err := svc.CompleteQuoteUpdate(ctx, "u1", func(context.Context) (domain.Quote, error) {
+		return domain.Quote{}, errors.New("upstream down")
+	}, "chan")
+	require.Error(t, err)
+
+	upd, err := svc.RetryQuoteUpdate(ctx, "u1", nil)
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateStatusQueued, upd.Status)
+	require.Nil(t, upd.Error)
+
+	_, err = svc.RetryQuoteUpdate(ctx, "u1", nil)
+	require.ErrorIs(t, err, ErrConflict)
+	_, err = svc.RetryQuoteUpdate(ctx, "missing", nil)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	ok, err := svc.StartQuoteUpdate(ctx, "u1")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, svc.CompleteQuoteUpdate(ctx, "u1", func(context.Context) (domain.Quote, error) {
+		return domain.Quote{Pair: "EUR/USD", Price: 1.1, UpdatedAt: time.Now()}, nil
+	}, "db"))
+
+	upd, err = svc.GetQuoteUpdate(ctx, "u1")
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateStatusDone, upd.Status)
+	require.Len(t, upd.Attempts, 2)
+	require.Equal(t, "worker-a", upd.Attempts[0].Worker)
+	require.NotNil(t, upd.Attempts[0].FinishedAt)
+	require.Equal(t, "upstream down", *upd.Attempts[0].Error)
+	require.Equal(t, 2, upd.Attempts[1].Number)
+	require.Equal(t, "worker-a", upd.Attempts[1].Worker)
+	require.Nil(t, upd.Attempts[1].Error)
+}
+
+func Test_CompleteQuoteUpdate_FinishesAttemptAfterTimeout(t *testing.T) {
+	t.Parallel()
+	jobs := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"u1": {ID: "u1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusProcessing},
+	}}
+	attempts := &fakeAttemptRepo{}
+	svc := NewService(&fakeQuoteRepo{store: map[string]domain.Quote{}}, jobs, &fakeRateProvider{}, nil,
+		WithAttempts(attempts))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	err := svc.CompleteQuoteUpdate(ctx, "u1", func(c context.Context) (domain.Quote, error) {
+		<-c.Done()
+		return domain.Quote{}, c.Err()
+	}, "chan")
+	require.Error(t, err)
+
+	list, err := attempts.List(context.Background(), "u1")
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, defaultWorkerID(), list[0].Worker)
+	require.NotNil(t, list[0].FinishedAt, "the attempt is closed even though its ctx expired")
+}
+
+func Test_CompleteQuoteUpdate_LabelsCachedSource(t *testing.T) {
+	t.Parallel()
+	qr := &fakeQuoteRepo{store: map[string]domain.Quote{}}
+	u := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"update-1": {ID: "update-1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusProcessing},
+	}}
+	svc := NewService(qr, u, &fakeRateProvider{}, nil)
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	err := svc.CompleteQuoteUpdate(context.Background(), "update-1", func(context.Context) (domain.Quote, error) {
+		return domain.Quote{Pair: "EUR/USD", Price: 1.1, UpdatedAt: ts, Cached: true}, nil
+	}, "db")
+	require.NoError(t, err)
+	require.Len(t, qr.history, 1)
+	require.Equal(t, "db:cache", qr.history[0].Source)
+	require.Equal(t, domain.QuoteUpdateStatusDone, u.jobs["update-1"].Status)
+}
+
+func Test_CompleteQuoteUpdate_TraceID(t *testing.T) {
+	t.Parallel()
+	u := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"update-1": {ID: "update-1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusProcessing},
+		"update-2": {ID: "update-2", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusProcessing},
+	}}
+	svc := NewService(&fakeQuoteRepo{store: map[string]domain.Quote{}}, u, &fakeRateProvider{}, nil)
+	fetchTrace := func(ctx context.Context, id string) string {
+		var got string
+		require.NoError(t, svc.CompleteQuoteUpdate(ctx, id, func(c context.Context) (domain.Quote, error) {
+			got = TraceIDFromContext(c)
+			return domain.Quote{Pair: "EUR/USD", Price: 1.1, UpdatedAt: time.Now()}, nil
+		}, "db"))
+		return got
+	}
+
+	require.Equal(t, "update-1", fetchTrace(context.Background(), "update-1"))
+	require.Equal(t, "req-trace", fetchTrace(ContextWithTraceID(context.Background(), "req-trace"), "update-2"))
+}
+
+func Test_CompleteQuoteUpdate_QuarantinesRejectedQuote(t *testing.T) {
+	t.Parallel()
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	qr := &fakeQuoteRepo{store: map[string]domain.Quote{
+		"EUR/USD": {Pair: "EUR/USD", Price: 1.0, UpdatedAt: ts},
+	}}
+	u := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"update-1": {ID: "update-1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusProcessing},
+	}}
+	qq := &fakeQuarantineRepo{}
+	svc := NewService(qr, u, &fakeRateProvider{}, nil, WithQuoteGuard(QuoteGuard{MaxDeviationPct: 10}, qq))
+
+	err := svc.CompleteQuoteUpdate(context.Background(), "update-1", func(context.Context) (domain.Quote, error) {
+		return domain.Quote{Pair: "EUR/USD", Price: 2.0, UpdatedAt: ts.Add(time.Minute)}, nil
+	}, "db")
+	var rej *QuoteRejectedError
+	require.ErrorAs(t, err, &rej)
+	require.Equal(t, domain.QuoteRejectDeviation, rej.Reason)
+
+	require.Equal(t, 1.0, qr.store["EUR/USD"].Price)
+	require.Empty(t, qr.history)
+	job := u.jobs["update-1"]
+	require.Equal(t, domain.QuoteUpdateStatusFailed, job.Status)
+	require.NotNil(t, job.Error)
+	require.Contains(t, *job.Error, "deviation_exceeded")
+	require.Len(t, qq.items, 1)
+	require.Equal(t, "update-1", *qq.items[0].UpdateID)
+}
+
+func Test_ReleaseQuarantinedQuote(t *testing.T) {
+	t.Parallel()
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	qr := &fakeQuoteRepo{store: map[string]domain.Quote{
+		"EUR/USD": {Pair: "EUR/USD", Price: 1.0, UpdatedAt: ts},
+	}}
+	u := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"update-1": {ID: "update-1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusFailed},
+	}}
+	qq := &fakeQuarantineRepo{}
+	_, _ = qq.Add(context.Background(), domain.QuarantinedQuote{
+		Pair: "EUR/USD", Price: 2.0, QuotedAt: ts.Add(time.Minute), Source: "db",
+		UpdateID: strPtr("update-1"), Reason: domain.QuoteRejectDeviation,
+	})
+	svc := NewService(qr, u, &fakeRateProvider{}, nil, WithQuoteGuard(QuoteGuard{MaxDeviationPct: 10}, qq))
+
+	got, err := svc.ReleaseQuarantinedQuote(context.Background(), 1)
+	require.NoError(t, err)
+	require.NotNil(t, got.ReleasedAt)
+	require.Equal(t, 2.0, qr.store["EUR/USD"].Price)
+	require.Len(t, qr.history, 1)
+	require.Equal(t, "db:released", qr.history[0].Source)
+	require.Equal(t, domain.QuoteUpdateStatusDone, u.jobs["update-1"].Status)
+
+	_, err = svc.ReleaseQuarantinedQuote(context.Background(), 1)
+	require.ErrorIs(t, err, ErrConflict)
+	_, err = svc.ReleaseQuarantinedQuote(context.Background(), 99)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
+
 func strPtr(s string) *string { return &s }
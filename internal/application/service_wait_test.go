@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WaitQuoteUpdate_WakesOnNotification(t *testing.T) {
+	t.Parallel()
+	jobs := &syncJobRepo{fakeUpdateJobRepo: &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"u1": {ID: "u1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusProcessing},
+	}}}
+	n := &fakeNotifier{}
+	svc := NewService(&fakeQuoteRepo{}, jobs, &fakeRateProvider{}, nil, WithUpdateNotifier(n))
+
+	go func() {
+		for !n.subscribed("u1") {
+			time.Sleep(time.Millisecond)
+		}
+		_ = jobs.UpdateStatus(context.Background(), "u1", domain.QuoteUpdateStatusDone, nil)
+		n.notify("u1")
+	}()
+
+	start := time.Now()
+	upd, err := svc.WaitQuoteUpdate(context.Background(), "u1", 10*time.Second)
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateStatusDone, upd.Status)
+	// Woken by the notification, well before the one-second recheck.
+	require.Less(t, time.Since(start), waitRecheckInterval)
+}
+
+func Test_WaitQuoteUpdate_ReturnsCurrentStateAfterWait(t *testing.T) {
+	t.Parallel()
+	jobs := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"u1": {ID: "u1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusQueued},
+		"u2": {ID: "u2", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusFailed},
+	}}
+	svc := NewService(&fakeQuoteRepo{}, jobs, &fakeRateProvider{}, nil, WithUpdateNotifier(&fakeNotifier{}))
+	ctx := context.Background()
+
+	upd, err := svc.WaitQuoteUpdate(ctx, "u1", 20*time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateStatusQueued, upd.Status)
+
+	upd, err = svc.WaitQuoteUpdate(ctx, "u2", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateStatusFailed, upd.Status)
+
+	_, err = svc.WaitQuoteUpdate(ctx, "missing", time.Second)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = svc.WaitQuoteUpdate(canceled, "u1", time.Second)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func Test_WatchQuoteUpdate_EmitsStatusChangesUntilTerminal(t *testing.T) {
+	t.Parallel()
+	jobs := &syncJobRepo{fakeUpdateJobRepo: &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"u1": {ID: "u1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusQueued},
+	}}}
+	n := &fakeNotifier{}
+	svc := NewService(&fakeQuoteRepo{}, jobs, &fakeRateProvider{}, nil, WithUpdateNotifier(n))
+	ctx := context.Background()
+
+	_, err := svc.WatchQuoteUpdate(ctx, "missing")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	ch, err := svc.WatchQuoteUpdate(ctx, "u1")
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateStatusQueued, (<-ch).Status)
+
+	for _, st := range []domain.QuoteUpdateStatus{domain.QuoteUpdateStatusProcessing, domain.QuoteUpdateStatusDone} {
+		require.NoError(t, jobs.UpdateStatus(ctx, "u1", st, nil))
+		n.notify("u1")
+		select {
+		case upd := <-ch:
+			require.Equal(t, st, upd.Status)
+		case <-time.After(waitRecheckInterval / 2):
+			t.Fatalf("no event for %s", st)
+		}
+	}
+	_, ok := <-ch
+	require.False(t, ok, "channel closes after a terminal status")
+}
@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func recv(t *testing.T, ch <-chan domain.QuoteHistory) domain.QuoteHistory {
+	t.Helper()
+	select {
+	case h, ok := <-ch:
+		require.True(t, ok, "stream closed")
+		return h
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+		return domain.QuoteHistory{}
+	}
+}
+
+func Test_WatchQuotes_ReplaysThenFollowsLive(t *testing.T) {
+	t.Parallel()
+	qr := &fakeQuoteRepo{}
+	for _, p := range []domain.Pair{"EUR/USD", "USD/MXN", "EUR/USD"} {
+		require.NoError(t, qr.AppendHistory(context.Background(), domain.QuoteHistory{Pair: p, Price: 1}))
+	}
+	feed := &fakeQuoteFeed{}
+	svc := NewService(qr, &fakeUpdateJobRepo{}, &fakeRateProvider{}, nil, WithQuoteFeed(feed))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	after := int64(1)
+	ch, err := svc.WatchQuotes(ctx, []string{"EUR/USD"}, &after)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), recv(t, ch).ID)
+
+	// Live changes repeating the replayed tail or for other pairs are skipped.
+	feed.publish(domain.QuoteHistory{ID: 3, Pair: "EUR/USD"})
+	feed.publish(domain.QuoteHistory{ID: 4, Pair: "USD/MXN"})
+	feed.publish(domain.QuoteHistory{ID: 5, Pair: "EUR/USD", Price: 1.2})
+	h := recv(t, ch)
+	require.Equal(t, int64(5), h.ID)
+	require.Equal(t, 1.2, h.Price)
+
+	cancel()
+	require.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+}
+
+func Test_WatchQuotes_DeliversOutOfOrderCommits(t *testing.T) {
+	t.Parallel()
+	qr := &fakeQuoteRepo{}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, qr.AppendHistory(context.Background(), domain.QuoteHistory{Pair: "EUR/USD"}))
+	}
+	feed := &fakeQuoteFeed{}
+	svc := NewService(qr, &fakeUpdateJobRepo{}, &fakeRateProvider{}, nil, WithQuoteFeed(feed))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	after := int64(2)
+	ch, err := svc.WatchQuotes(ctx, nil, &after)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), recv(t, ch).ID)
+
+	// The transaction holding id 5 committed before the one holding id 4.
+	feed.publish(domain.QuoteHistory{ID: 3, Pair: "EUR/USD"})
+	feed.publish(domain.QuoteHistory{ID: 5, Pair: "EUR/USD"})
+	feed.publish(domain.QuoteHistory{ID: 4, Pair: "EUR/USD"})
+	require.Equal(t, int64(5), recv(t, ch).ID)
+	require.Equal(t, int64(4), recv(t, ch).ID)
+}
+
+func Test_WatchQuotes_EndsAfterTruncatedReplay(t *testing.T) {
+	t.Parallel()
+	qr := &fakeQuoteRepo{}
+	for i := 0; i < maxQuoteReplay+1; i++ {
+		require.NoError(t, qr.AppendHistory(context.Background(), domain.QuoteHistory{Pair: "EUR/USD", Source: fmt.Sprint(i)}))
+	}
+	svc := NewService(qr, &fakeUpdateJobRepo{}, &fakeRateProvider{}, nil, WithQuoteFeed(&fakeQuoteFeed{}))
+
+	after := int64(0)
+	ch, err := svc.WatchQuotes(context.Background(), nil, &after)
+	require.NoError(t, err)
+	n := 0
+	for range ch {
+		n++
+	}
+	require.Equal(t, maxQuoteReplay, n)
+}
+
+func Test_WatchQuotes_RequiresFeed(t *testing.T) {
+	t.Parallel()
+	svc := NewService(&fakeQuoteRepo{}, &fakeUpdateJobRepo{}, &fakeRateProvider{}, nil)
+	_, err := svc.WatchQuotes(context.Background(), nil, nil)
+	require.ErrorIs(t, err, ErrQuoteFeedUnavailable)
+}
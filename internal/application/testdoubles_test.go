@@ -3,8 +3,15 @@ package application
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
 
 	"fxrates-service/internal/domain"
+
+	"github.com/google/uuid"
 )
 
 var (
@@ -12,8 +19,9 @@ var (
 )
 
 type fakeQuoteRepo struct {
-	store map[string]domain.Quote
-	err   error
+	store   map[string]domain.Quote
+	history []domain.QuoteHistory
+	err     error
 }
 
 func (f *fakeQuoteRepo) GetLast(_ context.Context, pair string) (domain.Quote, error) {
@@ -27,6 +35,19 @@ func (f *fakeQuoteRepo) GetLast(_ context.Context, pair string) (domain.Quote, e
 	return q, nil
 }
 
+func (f *fakeQuoteRepo) GetMany(_ context.Context, pairs []string) ([]domain.Quote, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var out []domain.Quote
+	for _, p := range pairs {
+		if q, ok := f.store[p]; ok {
+			out = append(out, q)
+		}
+	}
+	return out, nil
+}
+
 func (f *fakeQuoteRepo) Upsert(_ context.Context, q domain.Quote) error {
 	if f.err != nil {
 		return f.err
@@ -38,8 +59,34 @@ func (f *fakeQuoteRepo) Upsert(_ context.Context, q domain.Quote) error {
 	return nil
 }
 
-func (f *fakeQuoteRepo) AppendHistory(_ context.Context, _ domain.QuoteHistory) error {
-	return f.err
+func (f *fakeQuoteRepo) AppendHistory(_ context.Context, h domain.QuoteHistory) error {
+	if f.err != nil {
+		return f.err
+	}
+	h.ID = int64(len(f.history) + 1)
+	f.history = append(f.history, h)
+	return nil
+}
+
+func (f *fakeQuoteRepo) HistorySince(_ context.Context, afterID int64, pairs []string, limit int) ([]domain.QuoteHistory, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return historySince(f.history, afterID, pairs, limit), nil
+}
+
+func historySince(all []domain.QuoteHistory, afterID int64, pairs []string, limit int) []domain.QuoteHistory {
+	var out []domain.QuoteHistory
+	for _, h := range all {
+		if h.ID <= afterID || (len(pairs) > 0 && !slices.Contains(pairs, string(h.Pair))) {
+			continue
+		}
+		if len(out) == limit {
+			break
+		}
+		out = append(out, h)
+	}
+	return out
 }
 
 type fakeUpdateJobRepo struct {
@@ -101,6 +148,86 @@ func (f *fakeUpdateJobRepo) ClaimQueued(ctx context.Context, limit int) ([]struc
 	return out, nil
 }
 
+func (f *fakeUpdateJobRepo) ClaimByID(_ context.Context, id string) (bool, error) {
+	return f.transition(id, domain.QuoteUpdateStatusQueued, domain.QuoteUpdateStatusProcessing)
+}
+
+func (f *fakeUpdateJobRepo) CancelQueued(_ context.Context, id string) (bool, error) {
+	return f.transition(id, domain.QuoteUpdateStatusQueued, domain.QuoteUpdateStatusCanceled)
+}
+
+func (f *fakeUpdateJobRepo) RequeueFailed(_ context.Context, id string) (bool, error) {
+	return f.transition(id, domain.QuoteUpdateStatusFailed, domain.QuoteUpdateStatusQueued)
+}
+
+func (f *fakeUpdateJobRepo) transition(id string, from, to domain.QuoteUpdateStatus) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	j, ok := f.jobs[id]
+	if !ok {
+		return false, domain.ErrNotFound
+	}
+	if j.Status != from {
+		return false, nil
+	}
+	j.Status = to
+	if to == domain.QuoteUpdateStatusQueued {
+		j.Error = nil
+	}
+	f.jobs[id] = j
+	return true, nil
+}
+
+func (f *fakeUpdateJobRepo) List(_ context.Context, flt domain.QuoteUpdateFilter) ([]domain.QuoteUpdate, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	var out []domain.QuoteUpdate
+	for _, j := range f.jobs {
+		if fakeJobMatches(j, flt, true) {
+			out = append(out, j)
+		}
+	}
+	sort.Slice(out, func(i, k int) bool {
+		if !out[i].RequestedAt.Equal(out[k].RequestedAt) {
+			return out[i].RequestedAt.After(out[k].RequestedAt)
+		}
+		return out[i].ID > out[k].ID
+	})
+	if flt.Limit > 0 && len(out) > flt.Limit {
+		out = out[:flt.Limit]
+	}
+	return out, nil
+}
+
+func (f *fakeUpdateJobRepo) CountByStatus(_ context.Context, flt domain.QuoteUpdateFilter) (map[domain.QuoteUpdateStatus]int64, error) {
+	out := map[domain.QuoteUpdateStatus]int64{}
+	for _, j := range f.jobs {
+		if fakeJobMatches(j, flt, false) {
+			out[j.Status]++
+		}
+	}
+	return out, nil
+}
+
+func fakeJobMatches(j domain.QuoteUpdate, f domain.QuoteUpdateFilter, withStatus bool) bool {
+	switch {
+	case withStatus && f.Status != "" && j.Status != f.Status:
+		return false
+	case f.Pair != "" && j.Pair != f.Pair:
+		return false
+	case !f.From.IsZero() && j.RequestedAt.Before(f.From):
+		return false
+	case !f.To.IsZero() && !j.RequestedAt.Before(f.To):
+		return false
+	case withStatus && f.After != nil:
+		a := f.After
+		return j.RequestedAt.Before(a.RequestedAt) || (j.RequestedAt.Equal(a.RequestedAt) && j.ID < a.ID)
+	}
+	return true
+}
+
 type fakeRateProvider struct {
 	out domain.Quote
 	err error
@@ -112,3 +239,228 @@ func (f *fakeRateProvider) Get(context.Context, string) (domain.Quote, error) {
 	}
 	return f.out, nil
 }
+
+type fakeQuarantineRepo struct {
+	items []domain.QuarantinedQuote
+}
+
+func (f *fakeQuarantineRepo) Add(_ context.Context, q domain.QuarantinedQuote) (int64, error) {
+	q.ID = int64(len(f.items) + 1)
+	f.items = append(f.items, q)
+	return q.ID, nil
+}
+
+func (f *fakeQuarantineRepo) Get(_ context.Context, id int64) (domain.QuarantinedQuote, error) {
+	for _, q := range f.items {
+		if q.ID == id {
+			return q, nil
+		}
+	}
+	return domain.QuarantinedQuote{}, domain.ErrNotFound
+}
+
+func (f *fakeQuarantineRepo) ListPending(_ context.Context, pair string, limit int) ([]domain.QuarantinedQuote, error) {
+	var out []domain.QuarantinedQuote
+	for i := len(f.items) - 1; i >= 0 && len(out) < limit; i-- {
+		q := f.items[i]
+		if q.ReleasedAt == nil && (pair == "" || string(q.Pair) == pair) {
+			out = append(out, q)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeQuarantineRepo) MarkReleased(_ context.Context, id int64, at time.Time) (bool, error) {
+	for i := range f.items {
+		if f.items[i].ID == id {
+			if f.items[i].ReleasedAt != nil {
+				return false, nil
+			}
+			f.items[i].ReleasedAt = &at
+			return true, nil
+		}
+	}
+	return false, domain.ErrNotFound
+}
+
+// fakeUpdateBatchRepo stores member jobs in the shared fakeUpdateJobRepo so that status
+// changes made through UpdateStatus show up in Get.
+type fakeUpdateBatchRepo struct {
+	jobs    *fakeUpdateJobRepo
+	members map[string][]string
+}
+
+func (f *fakeUpdateBatchRepo) Create(context.Context) (string, error) {
+	if f.members == nil {
+		f.members = map[string][]string{}
+	}
+	id := fmt.Sprintf("batch-%d", len(f.members)+1)
+	f.members[id] = nil
+	return id, nil
+}
+
+func (f *fakeUpdateBatchRepo) AddQueued(_ context.Context, batchID, pair string) (string, error) {
+	if f.jobs.jobs == nil {
+		f.jobs.jobs = map[string]domain.QuoteUpdate{}
+	}
+	id := fmt.Sprintf("%s-%d", batchID, len(f.members[batchID])+1)
+	f.jobs.jobs[id] = domain.QuoteUpdate{ID: id, Pair: domain.Pair(pair), Status: domain.QuoteUpdateStatusQueued}
+	f.members[batchID] = append(f.members[batchID], id)
+	return id, nil
+}
+
+func (f *fakeUpdateBatchRepo) Get(_ context.Context, id string) (domain.QuoteUpdateBatch, error) {
+	ids, ok := f.members[id]
+	if !ok {
+		return domain.QuoteUpdateBatch{}, domain.ErrNotFound
+	}
+	b := domain.QuoteUpdateBatch{ID: id}
+	for _, uid := range ids {
+		b.Updates = append(b.Updates, f.jobs.jobs[uid])
+	}
+	return b, nil
+}
+
+type fakeAttemptRepo struct {
+	attempts map[string][]domain.QuoteUpdateAttempt
+}
+
+func (f *fakeAttemptRepo) Start(_ context.Context, updateID, worker string, at time.Time) (int, error) {
+	if f.attempts == nil {
+		f.attempts = map[string][]domain.QuoteUpdateAttempt{}
+	}
+	n := len(f.attempts[updateID]) + 1
+	f.attempts[updateID] = append(f.attempts[updateID], domain.QuoteUpdateAttempt{Number: n, Worker: worker, StartedAt: at})
+	return n, nil
+}
+
+func (f *fakeAttemptRepo) Finish(ctx context.Context, updateID string, number int, at time.Time, errMsg *string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	as := f.attempts[updateID]
+	if number < 1 || number > len(as) {
+		return domain.ErrNotFound
+	}
+	as[number-1].FinishedAt = &at
+	as[number-1].Error = errMsg
+	return nil
+}
+
+func (f *fakeAttemptRepo) List(_ context.Context, updateID string) ([]domain.QuoteUpdateAttempt, error) {
+	return append([]domain.QuoteUpdateAttempt(nil), f.attempts[updateID]...), nil
+}
+
+// fakeNotifier lets tests fire status-change notifications by hand.
+type fakeNotifier struct {
+	mu   sync.Mutex
+	subs map[string][]chan struct{}
+}
+
+func (f *fakeNotifier) Subscribe(id string) (<-chan struct{}, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.subs == nil {
+		f.subs = map[string][]chan struct{}{}
+	}
+	ch := make(chan struct{}, 1)
+	f.subs[id] = append(f.subs[id], ch)
+	return ch, func() {}
+}
+
+func (f *fakeNotifier) subscribed(id string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subs[id]) > 0
+}
+
+func (f *fakeNotifier) notify(id string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, ch := range f.subs[id] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// syncJobRepo serializes the job reads and writes that tests make from several goroutines.
+type syncJobRepo struct {
+	mu sync.Mutex
+	*fakeUpdateJobRepo
+}
+
+func (s *syncJobRepo) GetByID(ctx context.Context, id string) (domain.QuoteUpdate, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fakeUpdateJobRepo.GetByID(ctx, id)
+}
+
+func (s *syncJobRepo) UpdateStatus(ctx context.Context, id string, st domain.QuoteUpdateStatus, errMsg *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fakeUpdateJobRepo.UpdateStatus(ctx, id, st, errMsg)
+}
+
+type fakeQuoteFeed struct {
+	mu   sync.Mutex
+	subs []chan domain.QuoteHistory
+}
+
+func (f *fakeQuoteFeed) Subscribe() (<-chan domain.QuoteHistory, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan domain.QuoteHistory, 16)
+	f.subs = append(f.subs, ch)
+	return ch, func() {}
+}
+
+func (f *fakeQuoteFeed) publish(h domain.QuoteHistory) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, ch := range f.subs {
+		ch <- h
+	}
+}
+
+type fakeAPIKeyRepo struct {
+	keys   map[string]domain.APIKey
+	hashes map[string]string
+}
+
+func (f *fakeAPIKeyRepo) Create(_ context.Context, k domain.APIKey, hash string) (string, error) {
+	if f.keys == nil {
+		f.keys, f.hashes = map[string]domain.APIKey{}, map[string]string{}
+	}
+	k.ID = uuid.NewString()
+	f.keys[k.ID] = k
+	f.hashes[hash] = k.ID
+	return k.ID, nil
+}
+
+func (f *fakeAPIKeyRepo) GetByHash(_ context.Context, hash string) (domain.APIKey, error) {
+	id, ok := f.hashes[hash]
+	if !ok {
+		return domain.APIKey{}, domain.ErrNotFound
+	}
+	return f.keys[id], nil
+}
+
+func (f *fakeAPIKeyRepo) List(context.Context) ([]domain.APIKey, error) {
+	var out []domain.APIKey
+	for _, k := range f.keys {
+		out = append(out, k)
+	}
+	return out, nil
+}
+
+func (f *fakeAPIKeyRepo) Revoke(_ context.Context, id string, at time.Time) (bool, error) {
+	k, ok := f.keys[id]
+	if !ok || k.RevokedAt != nil {
+		return false, nil
+	}
+	k.RevokedAt = &at
+	f.keys[id] = k
+	return true, nil
+}
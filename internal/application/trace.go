@@ -0,0 +1,20 @@
+package application
+
+import "context"
+
+type traceIDCtxKey struct{}
+
+// ContextWithTraceID attaches the trace ID of the request or update being worked on.
+// Outbound provider calls forward it (see httpx.TraceHeader).
+func ContextWithTraceID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, traceIDCtxKey{}, id)
+}
+
+// TraceIDFromContext returns the trace ID set by ContextWithTraceID, if any.
+func TraceIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDCtxKey{}).(string)
+	return id
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"fxrates-service/internal/application"
@@ -17,18 +18,24 @@ import (
 	"fxrates-service/internal/infrastructure/logx"
 	"fxrates-service/internal/infrastructure/pg"
 	"fxrates-service/internal/infrastructure/provider"
+	"fxrates-service/internal/infrastructure/redact"
 	redisstore "fxrates-service/internal/infrastructure/redis"
 	"fxrates-service/internal/infrastructure/worker"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 )
 
 // (no explicit Cleanup type needed; providers return func() for wire aggregation)
 
 type Repos struct {
-	QuoteRepo application.QuoteRepo
-	JobRepo   application.UpdateJobRepo
+	QuoteRepo  application.QuoteRepo
+	JobRepo    application.UpdateJobRepo
+	Quarantine application.QuarantineRepo
+	Batches    application.UpdateBatchRepo
+	Attempts   application.AttemptRepo
+	APIKeys    application.APIKeyRepo
 }
 
 type Services struct {
@@ -46,7 +53,11 @@ func atoiDef(s string, def int) int {
 
 func ProvideLogger() *zap.Logger { return logx.L() }
 
-func ProvideConfig() config.Config { return config.Load() }
+func ProvideConfig() config.Config {
+	cfg := config.Load()
+	redact.Register(cfg.ExchangeAPIKey, cfg.OXRAppID, cfg.RedisPassword, cfg.GRPCAPIKey, cfg.HTTPBearerToken)
+	return cfg
+}
 
 type ChanBus struct {
 	Ch       chan worker.UpdateMsg
@@ -104,11 +115,27 @@ func ProvideDB(ctx context.Context, log *zap.Logger, cfg config.Config) (*pg.DB,
 
 func ProvideRepos(db *pg.DB) Repos {
 	return Repos{
-		QuoteRepo: pg.NewQuoteRepo(db),
-		JobRepo:   pg.NewUpdateJobRepo(db),
+		QuoteRepo:  pg.NewQuoteRepo(db),
+		JobRepo:    pg.NewUpdateJobRepo(db),
+		Quarantine: pg.NewQuarantineRepo(db),
+		Batches:    pg.NewUpdateBatchRepo(db),
+		Attempts:   pg.NewAttemptRepo(db),
+		APIKeys:    pg.NewAPIKeyRepo(db),
 	}
 }
 
+// ProvideUpdateListener exposes Postgres status notifications to long-polling readers.
+func ProvideUpdateListener(db *pg.DB) (application.UpdateNotifier, func()) {
+	l := pg.NewUpdateListener(db)
+	return l, l.Close
+}
+
+// ProvideQuoteFeed fans stored quote changes out to streaming clients.
+func ProvideQuoteFeed(db *pg.DB) (application.QuoteFeed, func()) {
+	l := pg.NewQuoteListener(db)
+	return l, l.Close
+}
+
 func ProvideUoW(db *pg.DB) application.UnitOfWork {
 	return &pg.UnitOfWork{Pool: db.Pool}
 }
@@ -129,26 +156,174 @@ func ProvideIdempotency(client *redis.Client, cfg config.Config) Services {
 
 // BuildCleanup is not needed when using wire's built-in cleanup aggregation.
 
-func ProvideRateProvider(cfg config.Config) (application.RateProvider, error) {
-	switch cfg.Provider {
+// ProvideProviderHealth selects where provider call statistics live. With Redis they are
+// also kept in memory, which the fallback ranks by, and shared so that the API can report
+// on calls made by a separate worker process.
+func ProvideProviderHealth(cfg config.Config, client *redis.Client) (application.ProviderHealthStore, error) {
+	switch cfg.ProviderHealth {
+	case "", "redis":
+		return provider.NewWriteThroughHealth(redisstore.NewProviderHealthStore(client)), nil
+	case "memory":
+		return provider.NewMemoryHealth(), nil
+	default:
+		return nil, fmt.Errorf("unsupported PROVIDER_HEALTH=%q", cfg.ProviderHealth)
+	}
+}
+
+func ProvideRateProvider(cfg config.Config, client *redis.Client, health application.ProviderHealthStore) (application.RateProvider, error) {
+	var chain []*provider.Instrumented
+	for _, name := range strings.Split(cfg.Provider, ",") {
+		name = strings.TrimSpace(name)
+		p, err := provideBaseRateProvider(cfg, name)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, provider.NewInstrumented(name, p, health))
+	}
+	var base application.RateProvider = chain[0]
+	if len(chain) > 1 {
+		// Rank by what this process saw; reading Redis on every quote would cost a round trip.
+		ranking := health
+		if wt, ok := health.(*provider.WriteThroughHealth); ok {
+			ranking = wt.Local
+		}
+		base = provider.NewFallback(ranking, chain...)
+	}
+	switch cfg.ProviderCache {
+	case "memory":
+		return provider.NewCaching(base, provider.NewMemoryCache(), cfg.ProviderCacheTTL), nil
+	case "redis":
+		return provider.NewCaching(base, redisstore.NewQuoteCache(client), cfg.ProviderCacheTTL), nil
+	case "", "off":
+		return base, nil
+	default:
+		return nil, fmt.Errorf("unsupported PROVIDER_CACHE=%q", cfg.ProviderCache)
+	}
+}
+
+// providerHTTPClient builds the outbound client for real providers, wrapping the transport
+// with the fixture recorder when HTTP_RECORD_MODE is record or replay.
+func providerHTTPClient(cfg config.Config) *http.Client {
+	c := &http.Client{Timeout: 4 * time.Second}
+	switch mode := httpx.RecordMode(cfg.HTTPRecordMode); mode {
+	case httpx.RecordRecord, httpx.RecordReplay:
+		c.Transport = &httpx.Recorder{Dir: cfg.HTTPFixturesDir, Mode: mode}
+	}
+	return c
+}
+
+// providerMiddleware builds the outbound middleware chain listed in HTTP_MIDDLEWARES.
+func providerMiddleware(cfg config.Config) ([]httpx.Middleware, error) {
+	var mws []httpx.Middleware
+	for _, name := range strings.Split(cfg.HTTPMiddlewares, ",") {
+		switch name = strings.TrimSpace(name); name {
+		case "":
+		case "trace":
+			mws = append(mws, httpx.TraceHeader(""))
+		case "user_agent":
+			mws = append(mws, httpx.UserAgent(cfg.HTTPUserAgent))
+		case "bearer":
+			if cfg.HTTPBearerToken == "" {
+				return nil, fmt.Errorf("HTTP_MIDDLEWARES entry %q needs HTTP_BEARER_TOKEN", name)
+			}
+			mws = append(mws, httpx.BearerToken(cfg.HTTPBearerToken))
+		case "logging":
+			mws = append(mws, httpx.Logging())
+		case "metrics":
+			mws = append(mws, httpx.Metrics(logProviderMetric))
+		case "conditional":
+			mws = append(mws, httpx.Conditional(httpx.NewValidatorCache(cfg.HTTPCacheSize)))
+		default:
+			return nil, fmt.Errorf("unsupported HTTP_MIDDLEWARES entry %q", name)
+		}
+	}
+	return mws, nil
+}
+
+// logProviderMetric emits one "provider_http.metric" entry per round trip. Unlike the
+// logging middleware it carries no URL, only the fields a log-based metric needs.
+func logProviderMetric(m httpx.RequestMetric) {
+	logx.L().Info("provider_http.metric",
+		zap.String("method", m.Method),
+		zap.String("host", m.Host),
+		zap.Int("status", m.Status),
+		zap.Float64("duration_ms", float64(m.Duration)/float64(time.Millisecond)),
+		zap.Bool("error", m.Err != nil),
+	)
+}
+
+func providerRetryPolicy(cfg config.Config) *httpx.RetryPolicy {
+	return &httpx.RetryPolicy{
+		Initial:        cfg.HTTPBackoffInitial,
+		Max:            cfg.HTTPBackoffMax,
+		Total:          cfg.HTTPBackoffTotal,
+		AttemptTimeout: cfg.HTTPAttemptTimeout,
+		MaxAttempts:    cfg.HTTPMaxAttempts,
+	}
+}
+
+func provideBaseRateProvider(cfg config.Config, name string) (application.RateProvider, error) {
+	mws, err := providerMiddleware(cfg)
+	if err != nil {
+		return nil, err
+	}
+	switch name {
 	case "exchangeratesapi":
 		return &provider.ExchangeRatesAPIProvider{
 			BaseURL: cfg.ExchangeAPIBase,
 			APIKey:  cfg.ExchangeAPIKey,
-			Client:  &httpx.Client{HTTP: &http.Client{Timeout: 4 * time.Second}},
-			BackoffCfg: &httpx.BackoffConfig{
-				Initial: cfg.HTTPBackoffInitial,
-				Max:     cfg.HTTPBackoffMax,
-				Total:   cfg.HTTPBackoffTotal,
-			},
+			Client:  &httpx.Client{HTTP: providerHTTPClient(cfg), Middleware: mws},
+			Retry:   providerRetryPolicy(cfg),
+		}, nil
+	case "ecb":
+		return &provider.ECBProvider{
+			BaseURL: cfg.ECBAPIBase,
+			Client:  &httpx.Client{HTTP: providerHTTPClient(cfg), Middleware: mws},
+			Retry:   providerRetryPolicy(cfg),
+		}, nil
+	case "openexchangerates":
+		return &provider.OpenExchangeRatesProvider{
+			BaseURL: cfg.OXRAPIBase,
+			AppID:   cfg.OXRAppID,
+			Client:  &httpx.Client{HTTP: providerHTTPClient(cfg), Middleware: mws},
+			Retry:   providerRetryPolicy(cfg),
 		}, nil
+	case "sim":
+		sim, err := provider.NewSim(provider.SimConfig{
+			Mode:        provider.SimMode(cfg.SimMode),
+			Seed:        cfg.SimSeed,
+			StartPrice:  cfg.SimStartPrice,
+			Volatility:  cfg.SimVolatility,
+			ScriptFile:  cfg.SimScriptFile,
+			ShockEvery:  cfg.SimShockEvery,
+			ShockPct:    cfg.SimShockPct,
+			Latency:     cfg.SimLatency,
+			ErrorRate:   cfg.SimErrorRate,
+			TimeoutRate: cfg.SimTimeoutRate,
+			Hang:        cfg.SimHang,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return sim, nil
 	default:
 		return provider.NewFake(1.2345), nil
 	}
 }
 
-func ProvideFXRatesService(r Repos, rp application.RateProvider, s Services, u application.UnitOfWork) *application.FXRatesService {
-	return application.NewService(r.QuoteRepo, r.JobRepo, rp, s.Idem, application.WithUoW(u))
+func ProvideFXRatesService(cfg config.Config, r Repos, rp application.RateProvider, s Services, u application.UnitOfWork, health application.ProviderHealthStore, n application.UpdateNotifier, feed application.QuoteFeed) *application.FXRatesService {
+	return application.NewService(r.QuoteRepo, r.JobRepo, rp, s.Idem,
+		application.WithUoW(u),
+		application.WithRedactor(redact.String),
+		application.WithWarnLog(func(msg string, err error) { logx.L().Warn(msg, zap.Error(err)) }),
+		application.WithWorkerID(cfg.WorkerID),
+		application.WithProviderHealth(health),
+		application.WithQuoteGuard(application.QuoteGuard{MaxDeviationPct: cfg.QuoteMaxDeviationPct}, r.Quarantine),
+		application.WithUpdateBatches(r.Batches),
+		application.WithAttempts(r.Attempts),
+		application.WithUpdateNotifier(n),
+		application.WithQuoteFeed(feed),
+	)
 }
 
 // ProvideGRPCRateClient optionally dials the worker gRPC when WORKER_TYPE=grpc.
@@ -157,22 +332,68 @@ func ProvideGRPCRateClient(cfg config.Config) (*rateclient.Client, func(), error
 		return nil, func() {}, nil
 	}
 	ctx := context.Background()
-	c, cleanup, err := rateclient.New(ctx, cfg.GRPCTarget)
+	var opts []grpc.DialOption
+	if cfg.GRPCAPIKey != "" {
+		opts = append(opts, rateclient.WithAPIKey(cfg.GRPCAPIKey))
+	}
+	c, cleanup, err := rateclient.New(ctx, cfg.GRPCTarget, opts...)
 	if err != nil {
 		return nil, func() {}, err
 	}
 	return c, cleanup, nil
 }
 
+// GRPCBackend is what the gRPC worker needs from Postgres: the repos, for API keys and
+// WaitUpdate, and the status listener behind WaitUpdate. Fields are nil when unused.
+type GRPCBackend struct {
+	Repos   *Repos
+	Updates application.UpdateNotifier
+}
+
+// ProvideGRPCBackend connects to Postgres only when the gRPC worker needs it
+// (GRPC_WAIT_UPDATE or AUTH_ENABLED), so a fetch-only worker runs without a database.
+func ProvideGRPCBackend(ctx context.Context, log *zap.Logger, cfg config.Config) (GRPCBackend, func(), error) {
+	if !cfg.GRPCWaitUpdate && !cfg.AuthEnabled {
+		return GRPCBackend{}, func() {}, nil
+	}
+	db, closeDB, err := ProvideDB(ctx, log, cfg)
+	if err != nil {
+		return GRPCBackend{}, func() {}, err
+	}
+	r := ProvideRepos(db)
+	b := GRPCBackend{Repos: &r}
+	if !cfg.GRPCWaitUpdate {
+		return b, closeDB, nil
+	}
+	n, closeListener := ProvideUpdateListener(db)
+	b.Updates = n
+	return b, func() {
+		closeListener()
+		closeDB()
+	}, nil
+}
+
 // ProvideGRPCRateServerRunner returns a runner to start the gRPC worker server when WORKER_TYPE=grpc.
-// The bool indicates whether the runner is enabled.
-func ProvideGRPCRateServerRunner(cfg config.Config, rp application.RateProvider, log *zap.Logger) func(ctx context.Context) error {
+// WaitUpdate reports Unimplemented unless GRPC_WAIT_UPDATE is set.
+func ProvideGRPCRateServerRunner(cfg config.Config, rp application.RateProvider, b GRPCBackend, log *zap.Logger) func(ctx context.Context) error {
 	addr := cfg.GRPCAddr
 	return func(ctx context.Context) error {
-		// Build a minimal service for fetch-only gRPC without DB dependencies.
-		svc := application.NewService(nil, nil, rp, nil)
-		s := grpcserver.NewServer(svc, log)
-		return grpcserver.RunServer(ctx, addr, s, log)
+		opts := []application.Option{
+			application.WithRedactor(redact.String),
+			application.WithWorkerID(cfg.WorkerID),
+		}
+		// Fetch only needs the provider.
+		s := grpcserver.NewServer(application.NewService(nil, nil, rp, nil, opts...), log)
+		if b.Updates != nil {
+			opts = append(opts, application.WithAttempts(b.Repos.Attempts), application.WithUpdateNotifier(b.Updates))
+			svc := application.NewService(b.Repos.QuoteRepo, b.Repos.JobRepo, rp, nil, opts...)
+			s = grpcserver.NewServer(svc, log).WithUpdates(svc)
+		}
+		var sopts []grpc.ServerOption
+		if cfg.AuthEnabled {
+			sopts = append(sopts, grpc.UnaryInterceptor(grpcserver.AuthInterceptor(application.NewAPIKeys(b.Repos.APIKeys), log)))
+		}
+		return grpcserver.RunServer(ctx, addr, s, log, sopts...)
 	}
 }
 
@@ -196,11 +417,35 @@ func ProvideWorker(svc *application.FXRatesService, rp application.RateProvider,
 func ProvideAPIServer(
 	svc *application.FXRatesService,
 	cfg config.Config,
+	r Repos,
+	rc *redis.Client,
 	c *rateclient.Client,
 	bus *ChanBus,
 	log *zap.Logger,
 ) (*httpserver.Server, func(), error) {
 	s := httpserver.NewServer(svc)
+	s.SetStreamHeartbeat(cfg.SSEHeartbeat)
+	s.SetWSAllowedOrigins(strings.Split(cfg.WSAllowedOrigins, ",")...)
+	maxAge, err := httpserver.ParseCacheMaxAge(cfg.CacheMaxAge)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	s.SetCacheMaxAge(maxAge)
+	if cfg.AuthEnabled {
+		s.SetAuthenticator(application.NewAPIKeys(r.APIKeys))
+	}
+	clients, err := httpserver.ParseRateLimitClients(cfg.RateLimitClients)
+	if err != nil {
+		return nil, func() {}, err
+	}
+	if cfg.RateLimitRead > 0 || cfg.RateLimitWrite > 0 || len(clients) > 0 {
+		s.SetRateLimiter(redisstore.NewRateLimiter(rc), httpserver.RateLimitPolicy{
+			Window:     cfg.RateLimitWindow,
+			Default:    httpserver.RateLimits{Read: cfg.RateLimitRead, Write: cfg.RateLimitWrite},
+			Clients:    clients,
+			TrustProxy: cfg.RateLimitTrustProxy,
+		})
+	}
 	cleanup := func() {}
 
 	// Attach in-process chan worker mode
@@ -235,9 +480,15 @@ func ProvideAPIServer(
 		s.SetDispatcher(func(ctx context.Context, updateID, pair, traceID string) error {
 			timeout := cfg.RequestTimeout
 			go func() {
+				ok, err := svc.StartQuoteUpdate(context.Background(), updateID)
+				if err != nil || !ok {
+					logx.L().Info("grpc_complete_update.skip", zap.String("update_id", updateID), zap.Bool("queued", ok), zap.Error(err))
+					return
+				}
 				logx.L().Info("grpc_complete_update.start", zap.String("update_id", updateID), zap.String("pair", pair))
-				if err := svc.CompleteQuoteUpdate(context.Background(), updateID, func(cctx context.Context) (domain.Quote, error) {
-					res, err := c.Fetch(cctx, pair, traceID, timeout)
+				bctx := application.ContextWithTraceID(context.Background(), traceID)
+				if err := svc.CompleteQuoteUpdate(bctx, updateID, func(cctx context.Context) (domain.Quote, error) {
+					res, err := c.Fetch(cctx, pair, application.TraceIDFromContext(cctx), timeout)
 					if err != nil {
 						return domain.Quote{}, err
 					}
@@ -249,6 +500,7 @@ func ProvideAPIServer(
 						Pair:      domain.Pair(res.GetPair()),
 						Price:     res.GetPrice(),
 						UpdatedAt: t,
+						Cached:    res.GetCached(),
 					}, nil
 				}, "grpc"); err != nil {
 					logx.L().Error("grpc_complete_update.failed", zap.String("update_id", updateID), zap.Error(err))
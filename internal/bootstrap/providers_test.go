@@ -0,0 +1,47 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/config"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderMiddleware_ForwardsTraceIDAndBearer(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"timestamp":1731240000,"base":"EUR","rates":{"EUR":1,"USD":1.1}}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.Config{
+		ExchangeAPIBase: srv.URL,
+		ExchangeAPIKey:  "k",
+		HTTPMiddlewares: "trace,user_agent,bearer,metrics",
+		HTTPUserAgent:   "fxrates-test",
+		HTTPBearerToken: "tok",
+	}
+	p, err := provideBaseRateProvider(cfg, "exchangeratesapi")
+	require.NoError(t, err)
+	svc := application.NewService(nil, nil, p, nil)
+
+	ctx := application.ContextWithTraceID(context.Background(), "trace-123")
+	q, err := svc.FetchQuote(ctx, "EUR/USD")
+	require.NoError(t, err)
+	require.InDelta(t, 1.1, q.Price, 1e-9)
+	require.Equal(t, "trace-123", got.Get("X-Trace-Id"))
+	require.Equal(t, "Bearer tok", got.Get("Authorization"))
+	require.Equal(t, "fxrates-test", got.Get("User-Agent"))
+}
+
+func TestProviderMiddleware_BearerNeedsToken(t *testing.T) {
+	_, err := providerMiddleware(config.Config{HTTPMiddlewares: "bearer"})
+	require.Error(t, err)
+}
@@ -16,10 +16,13 @@ var infraSet = wire.NewSet(
 	ProvideConfig,
 	ProvideDB,
 	ProvideRepos,
+	ProvideUpdateListener,
+	ProvideQuoteFeed,
 	ProvideUoW,
 	ProvideRedisClient,
 	ProvideIdempotency,
 	ProvideChanBus,
+	ProvideProviderHealth,
 	ProvideRateProvider,
 	ProvideFXRatesService,
 	ProvideGRPCRateClient,
@@ -47,6 +50,7 @@ func InitDBWorker(ctx context.Context) (application.Worker, func(), error) {
 func InitGRPCRunner(ctx context.Context) (func(context.Context) error, func(), error) {
 	wire.Build(
 		infraSet,
+		ProvideGRPCBackend,
 		ProvideGRPCRateServerRunner,
 	)
 	return nil, nil, nil
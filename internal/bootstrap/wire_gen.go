@@ -17,41 +17,56 @@ import (
 
 // API injector: builds *httpserver.Server + Cleanup
 func InitAPI(ctx context.Context) (*httpserver.Server, func(), error) {
-	logger := ProvideLogger()
 	config := ProvideConfig()
+	logger := ProvideLogger()
 	db, cleanup, err := ProvideDB(ctx, logger, config)
 	if err != nil {
 		return nil, nil, err
 	}
 	repos := ProvideRepos(db)
-	rateProvider, err := ProvideRateProvider(config)
+	client, cleanup2, err := ProvideRedisClient(config)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
-	client, cleanup2, err := ProvideRedisClient(config)
+	providerHealthStore, err := ProvideProviderHealth(config, client)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	rateProvider, err := ProvideRateProvider(config, client, providerHealthStore)
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	services := ProvideIdempotency(client, config)
 	unitOfWork := ProvideUoW(db)
-	fxRatesService := ProvideFXRatesService(repos, rateProvider, services, unitOfWork)
-	rateclientClient, cleanup3, err := ProvideGRPCRateClient(config)
+	updateNotifier, cleanup3 := ProvideUpdateListener(db)
+	quoteFeed, cleanup4 := ProvideQuoteFeed(db)
+	fxRatesService := ProvideFXRatesService(config, repos, rateProvider, services, unitOfWork, providerHealthStore, updateNotifier, quoteFeed)
+	rateclientClient, cleanup5, err := ProvideGRPCRateClient(config)
 	if err != nil {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	chanBus := ProvideChanBus(config)
-	server, cleanup4, err := ProvideAPIServer(fxRatesService, config, rateclientClient, chanBus, logger)
+	server, cleanup6, err := ProvideAPIServer(fxRatesService, config, repos, client, rateclientClient, chanBus, logger)
 	if err != nil {
+		cleanup5()
+		cleanup4()
 		cleanup3()
 		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	return server, func() {
+		cleanup6()
+		cleanup5()
 		cleanup4()
 		cleanup3()
 		cleanup2()
@@ -61,28 +76,39 @@ func InitAPI(ctx context.Context) (*httpserver.Server, func(), error) {
 
 // DB Worker injector: builds application.Worker + Cleanup
 func InitDBWorker(ctx context.Context) (application.Worker, func(), error) {
-	logger := ProvideLogger()
 	config := ProvideConfig()
+	logger := ProvideLogger()
 	db, cleanup, err := ProvideDB(ctx, logger, config)
 	if err != nil {
 		return nil, nil, err
 	}
 	repos := ProvideRepos(db)
-	rateProvider, err := ProvideRateProvider(config)
+	client, cleanup2, err := ProvideRedisClient(config)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
-	client, cleanup2, err := ProvideRedisClient(config)
+	providerHealthStore, err := ProvideProviderHealth(config, client)
 	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
+	rateProvider, err := ProvideRateProvider(config, client, providerHealthStore)
+	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	services := ProvideIdempotency(client, config)
 	unitOfWork := ProvideUoW(db)
-	fxRatesService := ProvideFXRatesService(repos, rateProvider, services, unitOfWork)
+	updateNotifier, cleanup3 := ProvideUpdateListener(db)
+	quoteFeed, cleanup4 := ProvideQuoteFeed(db)
+	fxRatesService := ProvideFXRatesService(config, repos, rateProvider, services, unitOfWork, providerHealthStore, updateNotifier, quoteFeed)
 	worker := ProvideWorker(fxRatesService, rateProvider, logger, config)
 	return worker, func() {
+		cleanup4()
+		cleanup3()
 		cleanup2()
 		cleanup()
 	}, nil
@@ -91,13 +117,30 @@ func InitDBWorker(ctx context.Context) (application.Worker, func(), error) {
 // gRPC Runner injector: builds gRPC server runner + Cleanup
 func InitGRPCRunner(ctx context.Context) (func(context.Context) error, func(), error) {
 	config := ProvideConfig()
-	rateProvider, err := ProvideRateProvider(config)
+	client, cleanup, err := ProvideRedisClient(config)
 	if err != nil {
 		return nil, nil, err
 	}
+	providerHealthStore, err := ProvideProviderHealth(config, client)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	rateProvider, err := ProvideRateProvider(config, client, providerHealthStore)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
 	logger := ProvideLogger()
-	v := ProvideGRPCRateServerRunner(config, rateProvider, logger)
+	grpcBackend, cleanup2, err := ProvideGRPCBackend(ctx, logger, config)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	v := ProvideGRPCRateServerRunner(config, rateProvider, grpcBackend, logger)
 	return v, func() {
+		cleanup2()
+		cleanup()
 	}, nil
 }
 
@@ -108,10 +151,13 @@ var infraSet = wire.NewSet(
 	ProvideConfig,
 	ProvideDB,
 	ProvideRepos,
+	ProvideUpdateListener,
+	ProvideQuoteFeed,
 	ProvideUoW,
 	ProvideRedisClient,
 	ProvideIdempotency,
 	ProvideChanBus,
+	ProvideProviderHealth,
 	ProvideRateProvider,
 	ProvideFXRatesService,
 	ProvideGRPCRateClient,
@@ -15,23 +15,73 @@ type Config struct {
 	DatabaseURL string
 	// HTTP server
 	ShutdownTimeout time.Duration
+	SSEHeartbeat    time.Duration
+	// Cache-Control max-age per cacheable route, as "route=duration,..."
+	CacheMaxAge string
+	// Origins besides the API's own allowed to open /ws, comma-separated; "*" allows any
+	WSAllowedOrigins string
+	// API keys: AuthEnabled guards HTTP and gRPC; GRPCAPIKey is what the API sends to the worker
+	AuthEnabled bool
+	GRPCAPIKey  string
+	// Per-client rate limits per window (0 = unlimited); RateLimitClients overrides them
+	// as "client=read/write,..."
+	RateLimitRead    int
+	RateLimitWrite   int
+	RateLimitWindow  time.Duration
+	RateLimitClients string
+	// Take client IPs from X-Real-IP/X-Forwarded-For set by a reverse proxy
+	RateLimitTrustProxy bool
 	// Provider
 	Provider        string
 	ExchangeAPIBase string
 	ExchangeAPIKey  string
+	ECBAPIBase      string
+	OXRAPIBase      string
+	OXRAppID        string
+	// Simulation provider (PROVIDER=sim)
+	SimMode        string
+	SimSeed        int64
+	SimStartPrice  float64
+	SimVolatility  float64
+	SimScriptFile  string
+	SimShockEvery  int
+	SimShockPct    float64
+	SimLatency     time.Duration
+	SimErrorRate   float64
+	SimTimeoutRate float64
+	SimHang        time.Duration
+	// Provider health statistics: memory or redis
+	ProviderHealth string
+	// Provider response cache: off, memory or redis
+	ProviderCache    string
+	ProviderCacheTTL time.Duration
+	// Quote sanity guard; 0 disables the deviation check
+	QuoteMaxDeviationPct float64
+	// Provider HTTP record/replay: off, record or replay
+	HTTPRecordMode  string
+	HTTPFixturesDir string
 	// HTTP backoff for provider calls (milliseconds)
 	HTTPBackoffInitial time.Duration
 	HTTPBackoffMax     time.Duration
 	HTTPBackoffTotal   time.Duration
+	HTTPAttemptTimeout time.Duration
+	HTTPMaxAttempts    int
+	// Outbound middleware chain, outermost first: trace, user_agent, bearer, logging, metrics, conditional
+	HTTPMiddlewares string
+	HTTPUserAgent   string
+	HTTPBearerToken string
+	HTTPCacheSize   int
 	// PG pool sizing
 	PGMaxConns int
 	PGMinConns int
 	// Worker
 	WorkerType      string
+	WorkerID        string
 	WorkerPoll      time.Duration
 	WorkerBatchSize int
 	// gRPC
 	GRPCAddr       string
+	GRPCWaitUpdate bool
 	GRPCTarget     string
 	RequestTimeout time.Duration
 	// Redis (idempotency)
@@ -59,33 +109,79 @@ func atoiDef(s string, def int) int {
 	return i
 }
 
+func atofDef(s string, def float64) float64 {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return def
+	}
+	return f
+}
+
 // Load reads environment variables and applies defaults.
 func Load() Config {
 	return Config{
-		Env:                getEnv("ENV", "local"),
-		LogLevel:           getEnv("LOG_LEVEL", "info"),
-		Port:               getEnv("PORT", "8080"),
-		DatabaseURL:        getEnv("DATABASE_URL", ""),
-		ShutdownTimeout:    time.Duration(atoiDef(getEnv("SHUTDOWN_TIMEOUT_MS", "10000"), 10000)) * time.Millisecond,
-		Provider:           getEnv("PROVIDER", "fake"),
-		ExchangeAPIBase:    getEnv("EXCHANGE_API_BASE", "https://api.exchangeratesapi.io"),
-		ExchangeAPIKey:     getEnv("EXCHANGE_API_KEY", ""),
-		HTTPBackoffInitial: time.Duration(atoiDef(getEnv("HTTP_BACKOFF_INITIAL_MS", "200"), 200)) * time.Millisecond,
-		HTTPBackoffMax:     time.Duration(atoiDef(getEnv("HTTP_BACKOFF_MAX_MS", "1000"), 1000)) * time.Millisecond,
-		HTTPBackoffTotal:   time.Duration(atoiDef(getEnv("HTTP_BACKOFF_TOTAL_MS", "3000"), 3000)) * time.Millisecond,
-		PGMaxConns:         atoiDef(getEnv("PG_MAX_CONNS", "5"), 5),
-		PGMinConns:         atoiDef(getEnv("PG_MIN_CONNS", "1"), 1),
-		WorkerType:         getEnv("WORKER_TYPE", "db"),
-		WorkerPoll:         time.Duration(atoiDef(getEnv("WORKER_POLL_MS", "250"), 250)) * time.Millisecond,
-		WorkerBatchSize:    atoiDef(getEnv("WORKER_BATCH_LIMIT", "10"), 10),
-		GRPCAddr:           getEnv("GRPC_ADDR", ":9090"),
-		GRPCTarget:         getEnv("GRPC_TARGET", "dns:///worker:9090"),
-		RequestTimeout:     time.Duration(atoiDef(getEnv("REQUEST_TIMEOUT_MS", "3000"), 3000)) * time.Millisecond,
-		RedisAddr:          getEnv("REDIS_ADDR", "redis:6379"),
-		RedisPassword:      getEnv("REDIS_PASSWORD", ""),
-		RedisDB:            atoiDef(getEnv("REDIS_DB", "0"), 0),
-		RedisTTL:           time.Duration(atoiDef(getEnv("IDEMPOTENCY_TTL_MS", "86400000"), 86400000)) * time.Millisecond,
-		ChanQueueSize:      atoiDef(getEnv("CHAN_QUEUE_SIZE", "100"), 100),
-		ChanConcurrency:    atoiDef(getEnv("CHAN_CONCURRENCY", "2"), 2),
+		Env:                  getEnv("ENV", "local"),
+		LogLevel:             getEnv("LOG_LEVEL", "info"),
+		Port:                 getEnv("PORT", "8080"),
+		DatabaseURL:          getEnv("DATABASE_URL", ""),
+		ShutdownTimeout:      time.Duration(atoiDef(getEnv("SHUTDOWN_TIMEOUT_MS", "10000"), 10000)) * time.Millisecond,
+		SSEHeartbeat:         time.Duration(atoiDef(getEnv("SSE_HEARTBEAT_MS", "15000"), 15000)) * time.Millisecond,
+		CacheMaxAge:          getEnv("API_CACHE_MAX_AGE", ""),
+		WSAllowedOrigins:     getEnv("WS_ALLOWED_ORIGINS", ""),
+		AuthEnabled:          getEnv("AUTH_ENABLED", "false") == "true",
+		GRPCAPIKey:           getEnv("GRPC_API_KEY", ""),
+		RateLimitRead:        atoiDef(getEnv("RATE_LIMIT_READ", "0"), 0),
+		RateLimitWrite:       atoiDef(getEnv("RATE_LIMIT_WRITE", "0"), 0),
+		RateLimitWindow:      time.Duration(atoiDef(getEnv("RATE_LIMIT_WINDOW_MS", "60000"), 60000)) * time.Millisecond,
+		RateLimitClients:     getEnv("RATE_LIMIT_CLIENTS", ""),
+		RateLimitTrustProxy:  getEnv("RATE_LIMIT_TRUST_PROXY", "false") == "true",
+		Provider:             getEnv("PROVIDER", "fake"),
+		ExchangeAPIBase:      getEnv("EXCHANGE_API_BASE", "https://api.exchangeratesapi.io"),
+		ExchangeAPIKey:       getEnv("EXCHANGE_API_KEY", ""),
+		ECBAPIBase:           getEnv("ECB_API_BASE", "https://www.ecb.europa.eu"),
+		OXRAPIBase:           getEnv("OXR_API_BASE", "https://openexchangerates.org/api"),
+		OXRAppID:             getEnv("OXR_APP_ID", ""),
+		SimMode:              getEnv("SIM_MODE", "random_walk"),
+		SimSeed:              int64(atoiDef(getEnv("SIM_SEED", "1"), 1)),
+		SimStartPrice:        atofDef(getEnv("SIM_START_PRICE", "1.2345"), 1.2345),
+		SimVolatility:        atofDef(getEnv("SIM_VOLATILITY", "0.001"), 0.001),
+		SimScriptFile:        getEnv("SIM_SCRIPT_FILE", ""),
+		SimShockEvery:        atoiDef(getEnv("SIM_SHOCK_EVERY", "0"), 0),
+		SimShockPct:          atofDef(getEnv("SIM_SHOCK_PCT", "0.05"), 0.05),
+		SimLatency:           time.Duration(atoiDef(getEnv("SIM_LATENCY_MS", "0"), 0)) * time.Millisecond,
+		SimErrorRate:         atofDef(getEnv("SIM_ERROR_RATE", "0"), 0),
+		SimTimeoutRate:       atofDef(getEnv("SIM_TIMEOUT_RATE", "0"), 0),
+		SimHang:              time.Duration(atoiDef(getEnv("SIM_HANG_MS", "5000"), 5000)) * time.Millisecond,
+		ProviderHealth:       getEnv("PROVIDER_HEALTH", "redis"),
+		ProviderCache:        getEnv("PROVIDER_CACHE", "off"),
+		ProviderCacheTTL:     time.Duration(atoiDef(getEnv("PROVIDER_CACHE_TTL_MS", "1000"), 1000)) * time.Millisecond,
+		HTTPRecordMode:       getEnv("HTTP_RECORD_MODE", "off"),
+		HTTPFixturesDir:      getEnv("HTTP_FIXTURES_DIR", "ops/fixtures/provider"),
+		QuoteMaxDeviationPct: atofDef(getEnv("QUOTE_MAX_DEVIATION_PCT", "10"), 10),
+		HTTPBackoffInitial:   time.Duration(atoiDef(getEnv("HTTP_BACKOFF_INITIAL_MS", "200"), 200)) * time.Millisecond,
+		HTTPBackoffMax:       time.Duration(atoiDef(getEnv("HTTP_BACKOFF_MAX_MS", "1000"), 1000)) * time.Millisecond,
+		HTTPBackoffTotal:     time.Duration(atoiDef(getEnv("HTTP_BACKOFF_TOTAL_MS", "3000"), 3000)) * time.Millisecond,
+		HTTPAttemptTimeout:   time.Duration(atoiDef(getEnv("HTTP_ATTEMPT_TIMEOUT_MS", "1500"), 1500)) * time.Millisecond,
+		HTTPMaxAttempts:      atoiDef(getEnv("HTTP_MAX_ATTEMPTS", "0"), 0),
+		HTTPMiddlewares:      getEnv("HTTP_MIDDLEWARES", "trace,user_agent,conditional"),
+		HTTPUserAgent:        getEnv("HTTP_USER_AGENT", "fxrates-service"),
+		HTTPBearerToken:      getEnv("HTTP_BEARER_TOKEN", ""),
+		HTTPCacheSize:        atoiDef(getEnv("HTTP_VALIDATOR_CACHE_SIZE", "256"), 256),
+		PGMaxConns:           atoiDef(getEnv("PG_MAX_CONNS", "5"), 5),
+		PGMinConns:           atoiDef(getEnv("PG_MIN_CONNS", "1"), 1),
+		WorkerType:           getEnv("WORKER_TYPE", "db"),
+		WorkerID:             getEnv("WORKER_ID", ""),
+		WorkerPoll:           time.Duration(atoiDef(getEnv("WORKER_POLL_MS", "250"), 250)) * time.Millisecond,
+		WorkerBatchSize:      atoiDef(getEnv("WORKER_BATCH_LIMIT", "10"), 10),
+		GRPCAddr:             getEnv("GRPC_ADDR", ":9090"),
+		GRPCWaitUpdate:       getEnv("GRPC_WAIT_UPDATE", "false") == "true",
+		GRPCTarget:           getEnv("GRPC_TARGET", "dns:///worker:9090"),
+		RequestTimeout:       time.Duration(atoiDef(getEnv("REQUEST_TIMEOUT_MS", "3000"), 3000)) * time.Millisecond,
+		RedisAddr:            getEnv("REDIS_ADDR", "redis:6379"),
+		RedisPassword:        getEnv("REDIS_PASSWORD", ""),
+		RedisDB:              atoiDef(getEnv("REDIS_DB", "0"), 0),
+		RedisTTL:             time.Duration(atoiDef(getEnv("IDEMPOTENCY_TTL_MS", "86400000"), 86400000)) * time.Millisecond,
+		ChanQueueSize:        atoiDef(getEnv("CHAN_QUEUE_SIZE", "100"), 100),
+		ChanConcurrency:      atoiDef(getEnv("CHAN_CONCURRENCY", "2"), 2),
 	}
 }
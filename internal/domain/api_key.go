@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// Scope is a permission granted to an API key.
+type Scope string
+
+const (
+	ScopeQuotesRead  Scope = "quotes:read"
+	ScopeQuotesWrite Scope = "quotes:write"
+	// ScopeAdmin grants every other scope as well.
+	ScopeAdmin Scope = "admin"
+)
+
+// Valid reports whether s is a known scope.
+func (s Scope) Valid() bool {
+	switch s {
+	case ScopeQuotesRead, ScopeQuotesWrite, ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// APIKey identifies a client. The key itself is never stored, only its hash; Prefix is
+// kept so operators can recognize a key in listings.
+type APIKey struct {
+	ID        string
+	Name      string
+	Prefix    string
+	Scopes    []Scope
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+// Allows reports whether the key grants scope.
+func (k APIKey) Allows(scope Scope) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
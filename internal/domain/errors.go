@@ -5,4 +5,8 @@ import "errors"
 var (
 	ErrNotFound        = errors.New("not found")
 	ErrUnsupportedPair = errors.New("unsupported pair")
+	// ErrInvalidPair is a pair not written as BASE/QUOTE with two different codes.
+	ErrInvalidPair = errors.New("invalid pair")
+	// ErrUnsupportedCurrency is a well-formed pair with a currency that is not quoted.
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
 )
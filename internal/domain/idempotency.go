@@ -0,0 +1,15 @@
+package domain
+
+// IdempotencyRecord is kept per idempotency key: a fingerprint of the original request
+// and, once that request succeeded, the ids and status its response carried.
+type IdempotencyRecord struct {
+	Fingerprint string
+	UpdateID    string
+	BatchID     string
+	// Status of the original response; zero for records stored before it was kept.
+	Status int
+}
+
+// Completed reports whether the original request finished; until then retries cannot
+// be answered with its response.
+func (r IdempotencyRecord) Completed() bool { return r.UpdateID != "" || r.BatchID != "" }
@@ -1,6 +1,10 @@
 package domain
 
-import "regexp"
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
 
 type Pair string
 
@@ -12,13 +16,47 @@ var SupportedCurrency = map[string]bool{
 
 var pairRe = regexp.MustCompile(`^[A-Z]{3}/[A-Z]{3}$`)
 
-func ValidatePair(p string) bool {
+func ValidatePair(p string) bool { return CheckPair(p) == nil }
+
+// CheckPair tells why p is not a valid pair: ErrInvalidPair for a malformed pair or one
+// currency on both sides, ErrUnsupportedCurrency for a currency that is not quoted.
+func CheckPair(p string) error {
 	// First validate format via shared precompiled regex
 	if !pairRe.MatchString(p) {
-		return false
+		return ErrInvalidPair
 	}
-	// Then validate supported currencies and disallow identical base/quote
+	// Then disallow identical base/quote and validate supported currencies
 	base := p[:3]
 	quote := p[4:]
-	return SupportedCurrency[base] && SupportedCurrency[quote] && base != quote
+	if base == quote {
+		return ErrInvalidPair
+	}
+	if !SupportedCurrency[base] || !SupportedCurrency[quote] {
+		return ErrUnsupportedCurrency
+	}
+	return nil
+}
+
+// SupportedCurrencyList returns the supported currencies, sorted and comma-separated.
+func SupportedCurrencyList() string {
+	out := make([]string, 0, len(SupportedCurrency))
+	for c := range SupportedCurrency {
+		out = append(out, c)
+	}
+	sort.Strings(out)
+	return strings.Join(out, ", ")
+}
+
+// SupportedPairs lists every valid pair of supported currencies in sorted order.
+func SupportedPairs() []string {
+	var out []string
+	for base := range SupportedCurrency {
+		for quote := range SupportedCurrency {
+			if base != quote {
+				out = append(out, base+"/"+quote)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
 }
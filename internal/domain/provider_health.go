@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// HealthEWMAAlpha weights the latest call when rolling success rate and latency averages.
+const HealthEWMAAlpha = 0.2
+
+// ProviderCall is one observed RateProvider call. ErrorClass is empty on success.
+type ProviderCall struct {
+	Latency    time.Duration
+	ErrorClass string
+	At         time.Time
+}
+
+// ProviderHealth aggregates recent calls to one upstream provider.
+type ProviderHealth struct {
+	Provider string
+	Calls    int64
+	Failures int64
+	// SuccessRate is an exponentially weighted average of call outcomes (1 = success).
+	SuccessRate float64
+	// AvgLatency is an exponentially weighted average of call latency.
+	AvgLatency  time.Duration
+	LastSuccess *time.Time
+	LastFailure *time.Time
+	LastError   string
+}
+
+// Observe folds a call into the aggregate.
+func (h *ProviderHealth) Observe(c ProviderCall) {
+	outcome := 0.0
+	if c.ErrorClass == "" {
+		outcome = 1
+	}
+	if h.Calls == 0 {
+		h.SuccessRate = outcome
+		h.AvgLatency = c.Latency
+	} else {
+		h.SuccessRate += HealthEWMAAlpha * (outcome - h.SuccessRate)
+		h.AvgLatency += time.Duration(HealthEWMAAlpha * float64(c.Latency-h.AvgLatency))
+	}
+	h.Calls++
+	at := c.At.UTC()
+	if c.ErrorClass == "" {
+		h.LastSuccess = &at
+		return
+	}
+	h.Failures++
+	h.LastFailure = &at
+	h.LastError = c.ErrorClass
+}
+
+// Score ranks providers in [0,1]: the recent success rate discounted by average latency
+// (a provider averaging one second scores half of an instant one). Providers with no
+// recorded calls score 1 so that they get tried.
+func (h ProviderHealth) Score() float64 {
+	if h.Calls == 0 {
+		return 1
+	}
+	return h.SuccessRate / (1 + h.AvgLatency.Seconds())
+}
@@ -6,4 +6,7 @@ type Quote struct {
 	Pair      Pair
 	Price     float64
 	UpdatedAt time.Time
+	// Cached is set when the quote was served from a short-lived provider cache
+	// instead of a fresh upstream call.
+	Cached bool
 }
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// QuoteRejectReason is a stable, machine-readable reason for rejecting a provider quote.
+type QuoteRejectReason string
+
+const (
+	QuoteRejectNonPositivePrice QuoteRejectReason = "non_positive_price"
+	QuoteRejectDeviation        QuoteRejectReason = "deviation_exceeded"
+	QuoteRejectOutOfOrder       QuoteRejectReason = "out_of_order"
+)
+
+// QuarantinedQuote is a provider quote held back by the sanity guard.
+type QuarantinedQuote struct {
+	ID         int64
+	Pair       Pair
+	Price      float64
+	QuotedAt   time.Time
+	Source     string
+	UpdateID   *string
+	Reason     QuoteRejectReason
+	Detail     string
+	InsertedAt time.Time
+	ReleasedAt *time.Time
+}
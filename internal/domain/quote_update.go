@@ -3,10 +3,48 @@ package domain
 import "time"
 
 type QuoteUpdate struct {
-	ID        string
-	Pair      Pair
-	Status    QuoteUpdateStatus
-	Error     *string
-	Price     *float64
-	UpdatedAt time.Time
+	ID          string
+	Pair        Pair
+	Status      QuoteUpdateStatus
+	Error       *string
+	Price       *float64
+	UpdatedAt   time.Time
+	RequestedAt time.Time
+	// Attempts is only populated by reads that ask for the attempt history.
+	Attempts []QuoteUpdateAttempt
+}
+
+// QuoteUpdateAttempt records one processing run of an update job.
+type QuoteUpdateAttempt struct {
+	Number     int
+	Worker     string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+	Error      *string
+}
+
+// QuoteUpdateFilter selects update jobs for listing. Zero fields match everything.
+type QuoteUpdateFilter struct {
+	Status QuoteUpdateStatus
+	Pair   Pair
+	// From and To bound requested_at as [From, To).
+	From  time.Time
+	To    time.Time
+	Limit int
+	// After continues a listing right after the given job.
+	After *QuoteUpdateCursor
+}
+
+// QuoteUpdateCursor is a keyset position in a listing ordered by requested_at, id descending.
+type QuoteUpdateCursor struct {
+	RequestedAt time.Time
+	ID          string
+}
+
+// QuoteUpdatePage is one page of a listing. Counts covers every job matching the filter
+// apart from its status, so clients can render per-status totals next to the page.
+type QuoteUpdatePage struct {
+	Items  []QuoteUpdate
+	Next   *QuoteUpdateCursor
+	Counts map[QuoteUpdateStatus]int64
 }
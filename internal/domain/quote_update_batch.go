@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// QuoteUpdateBatchStatus aggregates the statuses of a batch's member updates.
+type QuoteUpdateBatchStatus string
+
+const (
+	// QuoteUpdateBatchPending means at least one member is queued or processing.
+	QuoteUpdateBatchPending QuoteUpdateBatchStatus = "pending"
+	QuoteUpdateBatchDone    QuoteUpdateBatchStatus = "done"
+	// QuoteUpdateBatchPartial means every member finished and some of them failed or were canceled.
+	QuoteUpdateBatchPartial QuoteUpdateBatchStatus = "partial"
+	QuoteUpdateBatchFailed  QuoteUpdateBatchStatus = "failed"
+)
+
+// QuoteUpdateBatch groups update requests created together under one idempotency key.
+type QuoteUpdateBatch struct {
+	ID        string
+	CreatedAt time.Time
+	Updates   []QuoteUpdate
+}
+
+// Status derives the batch status from its members.
+func (b QuoteUpdateBatch) Status() QuoteUpdateBatchStatus {
+	var done, failed int
+	for _, u := range b.Updates {
+		switch u.Status {
+		case QuoteUpdateStatusDone:
+			done++
+		case QuoteUpdateStatusFailed, QuoteUpdateStatusCanceled:
+			failed++
+		default:
+			return QuoteUpdateBatchPending
+		}
+	}
+	switch {
+	case failed == 0:
+		return QuoteUpdateBatchDone
+	case done == 0:
+		return QuoteUpdateBatchFailed
+	default:
+		return QuoteUpdateBatchPartial
+	}
+}
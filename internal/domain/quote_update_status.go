@@ -7,4 +7,15 @@ const (
 	QuoteUpdateStatusProcessing QuoteUpdateStatus = "processing"
 	QuoteUpdateStatusDone       QuoteUpdateStatus = "done"
 	QuoteUpdateStatusFailed     QuoteUpdateStatus = "failed"
+	// QuoteUpdateStatusCanceled marks a job withdrawn by the client before processing started.
+	QuoteUpdateStatusCanceled QuoteUpdateStatus = "canceled"
 )
+
+// Terminal reports whether no further status change is expected without a client action.
+func (s QuoteUpdateStatus) Terminal() bool {
+	switch s {
+	case QuoteUpdateStatusDone, QuoteUpdateStatusFailed, QuoteUpdateStatusCanceled:
+		return true
+	}
+	return false
+}
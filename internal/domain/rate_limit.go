@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// RateLimitDecision is the outcome of counting one request against a client's budget.
+type RateLimitDecision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is how long until the oldest request in the window expires and frees a slot.
+	Reset time.Duration
+}
@@ -8,6 +8,7 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 type Client struct {
@@ -15,12 +16,14 @@ type Client struct {
 	cli  ratepb.RateServiceClient
 }
 
-func New(ctx context.Context, target string) (*Client, func(), error) {
+func New(ctx context.Context, target string, opts ...grpc.DialOption) (*Client, func(), error) {
 	conn, err := grpc.DialContext(
 		ctx,
 		target,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"round_robin"}`),
+		append([]grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy":"round_robin"}`),
+		}, opts...)...,
 	)
 	if err != nil {
 		return nil, nil, err
@@ -36,3 +39,11 @@ func (c *Client) Fetch(ctx context.Context, pair, traceID string, timeout time.D
 	}
 	return c.cli.Fetch(ctx, &ratepb.FetchRequest{Pair: pair, TraceId: traceID})
 }
+
+// WithAPIKey sends key with every call, for servers that run AuthInterceptor.
+func WithAPIKey(key string) grpc.DialOption {
+	return grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+key)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+}
@@ -19,7 +19,8 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	RateService_Fetch_FullMethodName = "/fxrates.rate.v1.RateService/Fetch"
+	RateService_Fetch_FullMethodName      = "/fxrates.rate.v1.RateService/Fetch"
+	RateService_WaitUpdate_FullMethodName = "/fxrates.rate.v1.RateService/WaitUpdate"
 )
 
 // RateServiceClient is the client API for RateService service.
@@ -27,6 +28,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RateServiceClient interface {
 	Fetch(ctx context.Context, in *FetchRequest, opts ...grpc.CallOption) (*FetchResponse, error)
+	WaitUpdate(ctx context.Context, in *WaitUpdateRequest, opts ...grpc.CallOption) (*WaitUpdateResponse, error)
 }
 
 type rateServiceClient struct {
@@ -47,11 +49,22 @@ func (c *rateServiceClient) Fetch(ctx context.Context, in *FetchRequest, opts ..
 	return out, nil
 }
 
+func (c *rateServiceClient) WaitUpdate(ctx context.Context, in *WaitUpdateRequest, opts ...grpc.CallOption) (*WaitUpdateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WaitUpdateResponse)
+	err := c.cc.Invoke(ctx, RateService_WaitUpdate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RateServiceServer is the server API for RateService service.
 // All implementations must embed UnimplementedRateServiceServer
 // for forward compatibility
 type RateServiceServer interface {
 	Fetch(context.Context, *FetchRequest) (*FetchResponse, error)
+	WaitUpdate(context.Context, *WaitUpdateRequest) (*WaitUpdateResponse, error)
 	mustEmbedUnimplementedRateServiceServer()
 }
 
@@ -62,6 +75,9 @@ type UnimplementedRateServiceServer struct {
 func (UnimplementedRateServiceServer) Fetch(context.Context, *FetchRequest) (*FetchResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Fetch not implemented")
 }
+func (UnimplementedRateServiceServer) WaitUpdate(context.Context, *WaitUpdateRequest) (*WaitUpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WaitUpdate not implemented")
+}
 func (UnimplementedRateServiceServer) mustEmbedUnimplementedRateServiceServer() {}
 
 // UnsafeRateServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -93,6 +109,24 @@ func _RateService_Fetch_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RateService_WaitUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RateServiceServer).WaitUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RateService_WaitUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RateServiceServer).WaitUpdate(ctx, req.(*WaitUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RateService_ServiceDesc is the grpc.ServiceDesc for RateService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -104,6 +138,10 @@ var RateService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Fetch",
 			Handler:    _RateService_Fetch_Handler,
 		},
+		{
+			MethodName: "WaitUpdate",
+			Handler:    _RateService_WaitUpdate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "rate.proto",
@@ -0,0 +1,78 @@
+package rateserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/grpc/ratepb"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodScopes maps RPCs to the scope they need; RPCs missing here need admin.
+var methodScopes = map[string]domain.Scope{
+	// Fetch spends provider quota on behalf of update processing.
+	ratepb.RateService_Fetch_FullMethodName:      domain.ScopeQuotesWrite,
+	ratepb.RateService_WaitUpdate_FullMethodName: domain.ScopeQuotesRead,
+}
+
+// AuthInterceptor requires an API key in the "authorization" ("Bearer <key>") or
+// "x-api-key" metadata and attaches the key to the handler context.
+func AuthInterceptor(auth application.Authenticator, log *zap.Logger) grpc.UnaryServerInterceptor {
+	if log == nil {
+		log = zap.NewNop()
+	}
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		log := log.With(zap.String("method", info.FullMethod))
+		md, _ := metadata.FromIncomingContext(ctx)
+		secret := apiKeyFromMetadata(md)
+		if secret == "" {
+			return nil, status.Error(codes.Unauthenticated, "API key required")
+		}
+		k, err := auth.Authenticate(ctx, secret)
+		switch {
+		case errors.Is(err, application.ErrUnauthenticated):
+			log.Warn("grpc_auth.invalid_key")
+			return nil, status.Error(codes.Unauthenticated, "invalid API key")
+		case err != nil:
+			log.Error("grpc_auth.failed", zap.Error(err))
+			return nil, status.Error(codes.Internal, "authentication failed")
+		}
+		scope, ok := methodScopes[info.FullMethod]
+		if !ok {
+			scope = domain.ScopeAdmin
+		}
+		if !k.Allows(scope) {
+			log.Warn("grpc_auth.missing_scope", zap.String("api_key_id", k.ID), zap.String("scope", string(scope)))
+			return nil, status.Error(codes.PermissionDenied, "API key lacks scope "+string(scope))
+		}
+		return handler(application.ContextWithAPIKey(ctx, k), req)
+	}
+}
+
+func apiKeyFromMetadata(md metadata.MD) string {
+	if v := md.Get("authorization"); len(v) > 0 {
+		if key, ok := strings.CutPrefix(v[0], "Bearer "); ok {
+			return strings.TrimSpace(key)
+		}
+	}
+	if v := md.Get("x-api-key"); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// logFor adds the calling key, when there is one, to the server logger.
+func (s *Server) logFor(ctx context.Context) *zap.Logger {
+	if k, ok := application.APIKeyFromContext(ctx); ok {
+		return s.log.With(zap.String("api_key_id", k.ID), zap.String("api_key_name", k.Name))
+	}
+	return s.log
+}
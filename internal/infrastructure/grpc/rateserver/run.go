@@ -11,8 +11,9 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-// RunServer starts a gRPC server and blocks until context is done.
-func RunServer(ctx context.Context, addr string, srv ratepb.RateServiceServer, log *zap.Logger) error {
+// RunServer starts a gRPC server and blocks until context is done. opts are added to the
+// server's own, e.g. AuthInterceptor.
+func RunServer(ctx context.Context, addr string, srv ratepb.RateServiceServer, log *zap.Logger, opts ...grpc.ServerOption) error {
 	if log == nil {
 		log = zap.NewNop()
 	}
@@ -20,7 +21,7 @@ func RunServer(ctx context.Context, addr string, srv ratepb.RateServiceServer, l
 	if err != nil {
 		return err
 	}
-	gs := grpc.NewServer(grpc.Creds(insecure.NewCredentials()))
+	gs := grpc.NewServer(append([]grpc.ServerOption{grpc.Creds(insecure.NewCredentials())}, opts...)...)
 	ratepb.RegisterRateServiceServer(gs, srv)
 	errCh := make(chan error, 1)
 	go func() {
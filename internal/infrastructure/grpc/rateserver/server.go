@@ -2,17 +2,23 @@ package rateserver
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
 	"fxrates-service/internal/infrastructure/grpc/ratepb"
+	"fxrates-service/internal/infrastructure/redact"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Server struct {
-	svc application.QuoteFetcher
-	log *zap.Logger
+	svc     application.QuoteFetcher
+	updates application.UpdateWaiter
+	log     *zap.Logger
 	ratepb.UnimplementedRateServiceServer
 }
 
@@ -25,20 +31,64 @@ func NewServer(svc application.QuoteFetcher, log *zap.Logger) *Server {
 }
 
 func (s *Server) Fetch(ctx context.Context, req *ratepb.FetchRequest) (*ratepb.FetchResponse, error) {
-	log := s.log
+	log := s.logFor(ctx)
 	pair := req.GetPair()
 	traceID := req.GetTraceId()
 	log = log.With(zap.String("pair", pair), zap.String("trace_id", traceID))
 
-	q, err := s.svc.FetchQuote(ctx, pair)
+	q, err := s.svc.FetchQuote(application.ContextWithTraceID(ctx, traceID), pair)
 	if err != nil {
 		log.Warn("grpc_fetch.provider_error", zap.Error(err))
-		return nil, err
+		// The message crosses a process boundary and ends up in the job record.
+		return nil, status.Error(codes.Unknown, redact.Error(err))
 	}
 	log.Info("grpc_fetch.success", zap.Float64("price", q.Price))
 	return &ratepb.FetchResponse{
 		Pair:      string(q.Pair),
 		Price:     q.Price,
 		UpdatedAt: q.UpdatedAt.Format(time.RFC3339Nano),
+		Cached:    q.Cached,
 	}, nil
 }
+
+// WithUpdates enables WaitUpdate; without it the RPC reports Unimplemented.
+func (s *Server) WithUpdates(u application.UpdateWaiter) *Server {
+	s.updates = u
+	return s
+}
+
+func (s *Server) WaitUpdate(ctx context.Context, req *ratepb.WaitUpdateRequest) (*ratepb.WaitUpdateResponse, error) {
+	if s.updates == nil {
+		return nil, status.Error(codes.Unimplemented, "update waits are not enabled on this server")
+	}
+	id := req.GetUpdateId()
+	if id == "" || req.GetWaitMs() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "update_id is required and wait_ms must not be negative")
+	}
+	log := s.logFor(ctx).With(zap.String("update_id", id), zap.Int64("wait_ms", req.GetWaitMs()))
+	upd, err := s.updates.WaitQuoteUpdate(ctx, id, time.Duration(req.GetWaitMs())*time.Millisecond)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return nil, status.Error(codes.NotFound, "update not found")
+	case ctx.Err() != nil:
+		return nil, status.FromContextError(ctx.Err()).Err()
+	case err != nil:
+		log.Warn("grpc_wait_update.failed", zap.Error(err))
+		return nil, status.Error(codes.Internal, redact.Error(err))
+	}
+	log.Info("grpc_wait_update.done", zap.String("status", string(upd.Status)))
+	resp := &ratepb.WaitUpdateResponse{
+		UpdateId:  upd.ID,
+		Pair:      string(upd.Pair),
+		Status:    string(upd.Status),
+		UpdatedAt: upd.UpdatedAt.Format(time.RFC3339Nano),
+		Terminal:  upd.Status.Terminal(),
+	}
+	if upd.Price != nil {
+		resp.Price = *upd.Price
+	}
+	if upd.Error != nil {
+		resp.Error = *upd.Error
+	}
+	return resp, nil
+}
@@ -13,7 +13,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 )
 
@@ -57,3 +60,95 @@ func TestRateServer_Fetch(t *testing.T) {
 	_, err = time.Parse(time.RFC3339Nano, resp.GetUpdatedAt())
 	require.NoError(t, err)
 }
+
+type fakeWaiter struct {
+	jobs map[string]domain.QuoteUpdate
+	got  time.Duration
+}
+
+func (f *fakeWaiter) WaitQuoteUpdate(_ context.Context, id string, wait time.Duration) (domain.QuoteUpdate, error) {
+	f.got = wait
+	u, ok := f.jobs[id]
+	if !ok {
+		return domain.QuoteUpdate{}, domain.ErrNotFound
+	}
+	return u, nil
+}
+
+func TestRateServer_WaitUpdate(t *testing.T) {
+	price := 1.1
+	w := &fakeWaiter{jobs: map[string]domain.QuoteUpdate{
+		"u1": {ID: "u1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusDone, Price: &price, UpdatedAt: time.Now()},
+	}}
+	cli := dial(t, NewServer(fakeFetcher{}, zap.NewNop()).WithUpdates(w))
+	ctx := context.Background()
+
+	resp, err := cli.WaitUpdate(ctx, &ratepb.WaitUpdateRequest{UpdateId: "u1", WaitMs: 1500})
+	require.NoError(t, err)
+	require.Equal(t, 1500*time.Millisecond, w.got)
+	require.Equal(t, "done", resp.GetStatus())
+	require.True(t, resp.GetTerminal())
+	require.InDelta(t, 1.1, resp.GetPrice(), 0.000001)
+
+	_, err = cli.WaitUpdate(ctx, &ratepb.WaitUpdateRequest{UpdateId: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = cli.WaitUpdate(ctx, &ratepb.WaitUpdateRequest{UpdateId: "u1", WaitMs: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = dial(t, NewServer(fakeFetcher{}, zap.NewNop())).WaitUpdate(ctx, &ratepb.WaitUpdateRequest{UpdateId: "u1"})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+type fakeAuthenticator map[string]domain.APIKey
+
+func (f fakeAuthenticator) Authenticate(_ context.Context, secret string) (domain.APIKey, error) {
+	k, ok := f[secret]
+	if !ok {
+		return domain.APIKey{}, application.ErrUnauthenticated
+	}
+	return k, nil
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	auth := fakeAuthenticator{
+		"reader": {ID: "k1", Scopes: []domain.Scope{domain.ScopeQuotesRead}},
+		"writer": {ID: "k2", Scopes: []domain.Scope{domain.ScopeQuotesWrite}},
+	}
+	cli := dial(t, NewServer(fakeFetcher{}, zap.NewNop()), grpc.UnaryInterceptor(AuthInterceptor(auth, zap.NewNop())))
+	withKey := func(md ...string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), md...)
+	}
+	req := &ratepb.FetchRequest{Pair: "EUR/USD"}
+
+	_, err := cli.Fetch(context.Background(), req)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = cli.Fetch(withKey("authorization", "Bearer nope"), req)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = cli.Fetch(withKey("x-api-key", "reader"), req)
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = cli.Fetch(withKey("authorization", "Bearer writer"), req)
+	require.NoError(t, err)
+}
+
+// dial serves srv over an in-memory listener and returns a client for it.
+func dial(t *testing.T, srv ratepb.RateServiceServer, opts ...grpc.ServerOption) ratepb.RateServiceClient {
+	t.Helper()
+	const bufSize = 1024 * 1024
+	lis := bufconn.Listen(bufSize)
+	t.Cleanup(func() { _ = lis.Close() })
+
+	s := grpc.NewServer(opts...)
+	ratepb.RegisterRateServiceServer(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(func() { s.Stop() })
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return ratepb.NewRateServiceClient(conn)
+}
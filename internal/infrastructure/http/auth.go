@@ -0,0 +1,79 @@
+package httpserver
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// SetAuthenticator requires an API key on every route except health checks and the API docs.
+func (s *Server) SetAuthenticator(a application.Authenticator) { s.auth = a }
+
+// routeScope returns the scope a request needs; false means the route is public.
+// Unknown paths need a key too, so probing them reveals nothing.
+func routeScope(r *http.Request) (domain.Scope, bool) {
+	p := r.URL.Path
+	switch {
+	case p == "/healthz", p == "/readyz", p == "/openapi.yaml", p == "/swagger":
+		return "", false
+	case strings.HasPrefix(p, "/admin/"), p == "/providers/status":
+		return domain.ScopeAdmin, true
+	case r.Method == http.MethodGet, r.Method == http.MethodHead:
+		return domain.ScopeQuotesRead, true
+	default:
+		return domain.ScopeQuotesWrite, true
+	}
+}
+
+// apiKeyFromRequest accepts "Authorization: Bearer <key>" or "X-API-Key: <key>".
+func apiKeyFromRequest(r *http.Request) string {
+	if v, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(v)
+	}
+	return r.Header.Get("X-API-Key")
+}
+
+func authenticate(s *Server) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scope, protected := routeScope(r)
+			if s.auth == nil || !protected {
+				next.ServeHTTP(w, r)
+				return
+			}
+			log := loggerForRequest(r)
+			secret := apiKeyFromRequest(r)
+			if secret == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeProblem(w, r, problemUnauthenticated, "send an API key as Authorization: Bearer <key> or X-API-Key")
+				return
+			}
+			k, err := s.auth.Authenticate(r.Context(), secret)
+			switch {
+			case errors.Is(err, application.ErrUnauthenticated):
+				log.Warn("auth.invalid_key")
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeProblem(w, r, problemUnauthenticated, "API key is unknown or revoked")
+				return
+			case err != nil:
+				logRequestError(r, "authenticate failed", err)
+				writeProblem(w, r, problemInternal, "")
+				return
+			}
+			if !k.Allows(scope) {
+				log.Warn("auth.missing_scope", zap.String("api_key_id", k.ID), zap.String("scope", string(scope)))
+				writeProblem(w, r, problemForbidden, "API key lacks scope "+string(scope))
+				return
+			}
+			if sr, ok := w.(*statusRecorder); ok {
+				sr.apiKeyID = k.ID
+			}
+			next.ServeHTTP(w, r.WithContext(application.ContextWithAPIKey(r.Context(), k)))
+		})
+	}
+}
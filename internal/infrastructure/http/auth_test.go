@@ -0,0 +1,72 @@
+package httpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAuthenticator map[string]domain.APIKey
+
+func (f fakeAuthenticator) Authenticate(_ context.Context, secret string) (domain.APIKey, error) {
+	k, ok := f[secret]
+	if !ok {
+		return domain.APIKey{}, application.ErrUnauthenticated
+	}
+	return k, nil
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	svc, _, _, _ := NewInMemoryService()
+	srv := NewServer(svc)
+	srv.SetAuthenticator(fakeAuthenticator{
+		"reader": {ID: "k1", Scopes: []domain.Scope{domain.ScopeQuotesRead}},
+		"writer": {ID: "k2", Scopes: []domain.Scope{domain.ScopeQuotesRead, domain.ScopeQuotesWrite}},
+		"admin":  {ID: "k3", Scopes: []domain.Scope{domain.ScopeAdmin}},
+	})
+	h := NewRouter(srv)
+
+	do := func(method, path, key string) int {
+		var body *strings.Reader
+		if method == http.MethodPost {
+			body = strings.NewReader(`{"pair":"EUR/USD"}`)
+		} else {
+			body = strings.NewReader("")
+		}
+		req := httptest.NewRequest(method, path, body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Idempotency-Key", "k-"+key)
+		if key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	require.Equal(t, http.StatusOK, do(http.MethodGet, "/healthz", ""))
+	require.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/quotes/last?pair=EUR/USD", ""))
+	require.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/quotes/last?pair=EUR/USD", "bogus"))
+	require.Equal(t, http.StatusNotFound, do(http.MethodGet, "/quotes/last?pair=EUR/USD", "reader"))
+
+	require.Equal(t, http.StatusForbidden, do(http.MethodPost, "/quotes/updates", "reader"))
+	require.Equal(t, http.StatusAccepted, do(http.MethodPost, "/quotes/updates", "writer"))
+
+	require.Equal(t, http.StatusForbidden, do(http.MethodGet, "/admin/quarantine", "writer"))
+	require.Equal(t, http.StatusForbidden, do(http.MethodGet, "/providers/status", "writer"))
+	// admin implies every other scope.
+	require.Equal(t, http.StatusAccepted, do(http.MethodPost, "/quotes/updates", "admin"))
+
+	req := httptest.NewRequest(http.MethodGet, "/quotes/last?pair=EUR/USD", nil)
+	req.Header.Set("X-API-Key", "reader")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
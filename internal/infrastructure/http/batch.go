@@ -0,0 +1,126 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/http/openapi"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) RequestQuoteUpdateBatch(w http.ResponseWriter, r *http.Request, params openapi.RequestQuoteUpdateBatchParams) {
+	log := loggerForRequest(r)
+	var body openapi.QuoteUpdateBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		log.Warn("request_quote_update_batch.decode_failed", zap.Error(err))
+		writeProblem(w, r, problemMalformedBody, err.Error())
+		return
+	}
+	if len(body.Pairs) == 0 {
+		writeProblem(w, r, problemValidationFailed, "", fieldError("pairs", "is required"))
+		return
+	}
+	var (
+		pairType   problemType
+		pairErrors []openapi.FieldError
+	)
+	for i, p := range body.Pairs {
+		if domain.ValidatePair(p) {
+			continue
+		}
+		log.Warn("request_quote_update_batch.invalid_pair_format", zap.String("pair", p))
+		typ, fe := pairProblem(fmt.Sprintf("pairs[%d]", i), p)
+		// A malformed pair is the more basic mistake, so it names the problem.
+		if pairType != problemInvalidPair {
+			pairType = typ
+		}
+		pairErrors = append(pairErrors, fe)
+	}
+	if len(pairErrors) > 0 {
+		writeProblem(w, r, pairType, "", pairErrors...)
+		return
+	}
+	idem := params.XIdempotencyKey
+	if idem == "" {
+		writeProblem(w, r, problemValidationFailed, "", fieldError("X-Idempotency-Key", "is required"))
+		return
+	}
+	log = log.With(zap.String("idempotency_key", idem), zap.Int("pairs", len(body.Pairs)))
+	// As for single updates, a batch whose members cannot all be dispatched is withdrawn
+	// and its key freed, so the client learns of it and can retry.
+	var undispatched string
+	batch, status, replayed, err := s.svc.RequestQuoteUpdateBatch(r.Context(), body.Pairs, &idem, func(b domain.QuoteUpdateBatch) (int, error) {
+		log.Info("request_quote_update_batch.queued", zap.String("batch_id", b.ID))
+		if s.dispatch == nil {
+			return http.StatusAccepted, nil
+		}
+		traceID := getTraceIDFromContext(r.Context())
+		for _, u := range b.Updates {
+			if err := s.dispatch(r.Context(), u.ID, string(u.Pair), traceID); err != nil {
+				log.Warn("request_quote_update_batch.dispatch_failed", zap.String("update_id", u.ID), zap.Error(err))
+				undispatched = u.ID
+				return http.StatusServiceUnavailable, err
+			}
+		}
+		return http.StatusAccepted, nil
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, application.ErrBadRequest):
+			// Everything else was checked above; only the pair cap is left to the service.
+			writeProblem(w, r, problemValidationFailed, "", fieldError("pairs", "has too many distinct pairs"))
+		case errors.Is(err, application.ErrConflict):
+			writeProblem(w, r, problemIdempotencyConflict, "a request with this X-Idempotency-Key is still being processed")
+		case errors.Is(err, application.ErrIdempotencyMismatch):
+			log.Warn("request_quote_update_batch.idempotency_mismatch")
+			writeProblem(w, r, problemIdempotencyMismatch, "X-Idempotency-Key was used for a batch with other pairs")
+		default:
+			logRequestError(r, "request quote update batch failed", err)
+			writeProblem(w, r, problemInternal, "")
+		}
+		return
+	}
+	if status == http.StatusServiceUnavailable {
+		writeProblem(w, r, problemDispatchUnavailable, "no worker took update "+undispatched+", so batch "+batch.ID+" was canceled; retry with the same X-Idempotency-Key")
+		return
+	}
+	resp := openapi.QuoteUpdateBatchResponse{BatchId: batch.ID, UpdateIds: make([]string, 0, len(batch.Updates))}
+	for _, u := range batch.Updates {
+		resp.UpdateIds = append(resp.UpdateIds, u.ID)
+	}
+	if replayed {
+		log.Info("request_quote_update_batch.replayed", zap.String("batch_id", batch.ID))
+		w.Header().Set(replayedHeader, "true")
+	}
+	writeJSON(w, acceptedStatus(status), resp)
+}
+
+func (s *Server) GetQuoteUpdateBatch(w http.ResponseWriter, r *http.Request, id string) {
+	log := loggerForRequest(r).With(zap.String("batch_id", id))
+	b, err := s.svc.GetQuoteUpdateBatch(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			writeProblem(w, r, problemNotFound, "no batch with id "+id)
+			return
+		}
+		logRequestError(r, "get quote update batch failed", err)
+		writeProblem(w, r, problemInternal, "")
+		return
+	}
+	resp := openapi.QuoteUpdateBatch{
+		BatchId:   b.ID,
+		CreatedAt: b.CreatedAt,
+		Status:    openapi.QuoteUpdateBatchStatus(b.Status()),
+		Updates:   make([]openapi.QuoteUpdateDetails, 0, len(b.Updates)),
+	}
+	for _, u := range b.Updates {
+		resp.Updates = append(resp.Updates, toQuoteUpdateDetails(u))
+	}
+	log.Info("get_quote_update_batch.success", zap.String("status", string(resp.Status)))
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,122 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+
+	"github.com/stretchr/testify/require"
+)
+
+func postBatch(h http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/quotes/updates/batch", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Idempotency-Key", key)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestQuoteUpdateBatch_CreateAndAggregate(t *testing.T) {
+	ur := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}
+	svc := application.NewService(&fakeQuoteRepo{}, ur, fakeRateProvider{}, redisstore.NoopIdempotency{},
+		application.WithUpdateBatches(&fakeUpdateBatchRepo{jobs: ur}))
+	h := NewRouter(NewServer(svc))
+
+	rec := postBatch(h, "basket-1", `{"pairs":["USD/MXN","EUR/USD"]}`)
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var created struct {
+		BatchID   string   `json:"batch_id"`
+		UpdateIDs []string `json:"update_ids"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	require.Len(t, created.UpdateIDs, 2)
+
+	require.NoError(t, ur.UpdateStatus(context.Background(), created.UpdateIDs[0], domain.QuoteUpdateStatusDone, nil))
+	require.NoError(t, ur.UpdateStatus(context.Background(), created.UpdateIDs[1], domain.QuoteUpdateStatusDone, nil))
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/quotes/updates/batch/"+created.BatchID, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got struct {
+		Status  string `json:"status"`
+		Updates []struct {
+			Pair   string `json:"pair"`
+			Status string `json:"status"`
+		} `json:"updates"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, "done", got.Status)
+	require.Len(t, got.Updates, 2)
+	require.Equal(t, "EUR/USD", got.Updates[0].Pair)
+}
+
+func TestQuoteUpdateBatch_RejectsInvalidPair(t *testing.T) {
+	ur := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}
+	svc := application.NewService(&fakeQuoteRepo{}, ur, fakeRateProvider{}, redisstore.NoopIdempotency{},
+		application.WithUpdateBatches(&fakeUpdateBatchRepo{jobs: ur}))
+	h := NewRouter(NewServer(svc))
+
+	rec := postBatch(h, "basket-2", `{"pairs":["EUR/USD","GBP/XYZ"]}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	require.Empty(t, ur.jobs)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/quotes/updates/batch/nope", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestQuoteUpdateBatch_IdempotentReplay(t *testing.T) {
+	ur := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}
+	svc := application.NewService(&fakeQuoteRepo{}, ur, fakeRateProvider{}, &memIdem{},
+		application.WithUpdateBatches(&fakeUpdateBatchRepo{jobs: ur}))
+	h := NewRouter(NewServer(svc))
+
+	first := postBatch(h, "basket-1", `{"pairs":["USD/MXN","EUR/USD"]}`)
+	require.Equal(t, http.StatusAccepted, first.Code, first.Body.String())
+
+	again := postBatch(h, "basket-1", `{"pairs":["USD/MXN","EUR/USD"]}`)
+	require.Equal(t, http.StatusAccepted, again.Code)
+	require.Equal(t, "true", again.Header().Get("Idempotent-Replayed"))
+	require.JSONEq(t, first.Body.String(), again.Body.String())
+
+	other := postBatch(h, "basket-1", `{"pairs":["EUR/USD"]}`)
+	require.Equal(t, http.StatusUnprocessableEntity, other.Code)
+}
+
+func TestQuoteUpdateBatch_DispatchUnavailable(t *testing.T) {
+	ur := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}
+	svc := application.NewService(&fakeQuoteRepo{}, ur, fakeRateProvider{}, &memIdem{},
+		application.WithUpdateBatches(&fakeUpdateBatchRepo{jobs: ur}))
+	srv := NewServer(svc)
+	fail := true
+	srv.SetDispatcher(func(_ context.Context, _, pair, _ string) error {
+		if fail && pair == "EUR/USD" {
+			return errors.New("queue full")
+		}
+		return nil
+	})
+	h := NewRouter(srv)
+
+	rec := postBatch(h, "basket-1", `{"pairs":["USD/MXN","EUR/USD"]}`)
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	requireProblem(t, rec, problemDispatchUnavailable)
+	require.Len(t, ur.jobs, 2)
+	for id, j := range ur.jobs {
+		require.Equal(t, domain.QuoteUpdateStatusCanceled, j.Status, id)
+	}
+
+	fail = false
+	rec = postBatch(h, "basket-1", `{"pairs":["USD/MXN","EUR/USD"]}`)
+	require.Equal(t, http.StatusAccepted, rec.Code, "the retry queues a new batch")
+	require.Empty(t, rec.Header().Get(replayedHeader))
+	require.Len(t, ur.jobs, 4)
+}
@@ -0,0 +1,37 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCancelQuoteUpdate(t *testing.T) {
+	svc, _, ur, _ := NewInMemoryService()
+	ur.jobs["q"] = domain.QuoteUpdate{ID: "q", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusQueued}
+	ur.jobs["p"] = domain.QuoteUpdate{ID: "p", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusProcessing}
+	h := NewRouter(NewServer(svc))
+
+	del := func(id string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/quotes/updates/"+id, nil))
+		return rec
+	}
+
+	rec := del("q")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var got struct {
+		Status string `json:"status"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, "canceled", got.Status)
+
+	require.Equal(t, http.StatusConflict, del("q").Code)
+	require.Equal(t, http.StatusConflict, del("p").Code)
+	require.Equal(t, http.StatusNotFound, del("missing").Code)
+}
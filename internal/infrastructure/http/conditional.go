@@ -0,0 +1,110 @@
+package httpserver
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Routes whose responses carry validators and a configurable Cache-Control max-age.
+const (
+	cacheRouteLastQuote   = "/quotes/last"
+	cacheRouteLatest      = "/quotes/latest"
+	cacheRouteQuoteUpdate = "/quotes/updates/{id}"
+)
+
+var cacheRoutes = []string{cacheRouteLastQuote, cacheRouteLatest, cacheRouteQuoteUpdate}
+
+// SetCacheMaxAge sets how long clients may reuse responses of each cacheable route
+// without revalidating. Routes left out must always revalidate.
+func (s *Server) SetCacheMaxAge(m map[string]time.Duration) { s.maxAge = m }
+
+// ParseCacheMaxAge reads per-route max-ages written as "route=duration,...", e.g.
+// "/quotes/last=5s,/quotes/updates/{id}=0s".
+func ParseCacheMaxAge(spec string) (map[string]time.Duration, error) {
+	out := map[string]time.Duration{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		route, age, ok := strings.Cut(item, "=")
+		route = strings.TrimSpace(route)
+		if !ok || !slices.Contains(cacheRoutes, route) {
+			return nil, fmt.Errorf("cache max-age %q: want route=duration with route one of %s", item, strings.Join(cacheRoutes, ", "))
+		}
+		d, err := time.ParseDuration(strings.TrimSpace(age))
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("cache max-age %q: invalid duration", item)
+		}
+		out[route] = d
+	}
+	return out, nil
+}
+
+func (s *Server) cacheControl(route string) string {
+	cc := "no-cache"
+	if d := s.maxAge[route]; d >= time.Second {
+		cc = "max-age=" + strconv.Itoa(int(d/time.Second))
+	}
+	// Responses depend on the API key, so shared caches must not hand them to others.
+	if s.auth != nil {
+		cc = "private, " + cc
+	}
+	return cc
+}
+
+// writeCacheableJSON answers with v like writeJSON, adding an ETag over the body and
+// Last-Modified from modified. A client whose copy still matches gets 304 Not Modified.
+func (s *Server) writeCacheableJSON(w http.ResponseWriter, r *http.Request, route string, v any, modified time.Time) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		logRequestError(r, "encode response failed", err)
+		writeProblem(w, r, problemInternal, "")
+		return
+	}
+	// Same bytes as json.Encoder, so the body matches writeJSON.
+	body = append(body, '\n')
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:12]) + `"`
+
+	h := w.Header()
+	h.Set("ETag", etag)
+	if !modified.IsZero() {
+		h.Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+	h.Set("Cache-Control", s.cacheControl(route))
+	if notModified(r, etag, modified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// notModified evaluates If-None-Match and, only without it, If-Modified-Since (RFC 9110 13.2.2).
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, t := range strings.Split(inm, ",") {
+			// If-None-Match uses the weak comparison.
+			if t = strings.TrimSpace(t); t == "*" || strings.TrimPrefix(t, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || modified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	// Last-Modified has second precision, so compare at that precision.
+	return err == nil && !modified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,94 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalGet(t *testing.T) {
+	svc, qr, ur, _ := NewInMemoryService()
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 600_000_000, time.UTC)
+	qr.store["EUR/USD"] = domain.Quote{Pair: "EUR/USD", Price: 1.1, UpdatedAt: ts}
+	ur.jobs["u1"] = domain.QuoteUpdate{ID: "u1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusQueued, UpdatedAt: ts}
+	srv := NewServer(svc)
+	srv.SetCacheMaxAge(map[string]time.Duration{cacheRouteLastQuote: 5 * time.Second})
+	h := NewRouter(srv)
+
+	get := func(path string, header ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("/quotes/last?pair=EUR/USD")
+	require.Equal(t, http.StatusOK, rec.Code)
+	etag := rec.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	require.Equal(t, "Thu, 02 Jan 2025 03:04:05 GMT", rec.Header().Get("Last-Modified"))
+	require.Equal(t, "max-age=5", rec.Header().Get("Cache-Control"))
+
+	rec = get("/quotes/last?pair=EUR/USD", "If-None-Match", `"other", `+etag)
+	require.Equal(t, http.StatusNotModified, rec.Code)
+	require.Empty(t, rec.Body.String())
+	require.Equal(t, etag, rec.Header().Get("ETag"))
+	require.Equal(t, http.StatusNotModified, get("/quotes/last?pair=EUR/USD", "If-None-Match", "W/"+etag).Code)
+	require.Equal(t, http.StatusNotModified, get("/quotes/last?pair=EUR/USD", "If-Modified-Since", "Thu, 02 Jan 2025 03:04:05 GMT").Code)
+	require.Equal(t, http.StatusOK, get("/quotes/last?pair=EUR/USD", "If-Modified-Since", "Thu, 02 Jan 2025 03:04:04 GMT").Code)
+	// If-None-Match wins over If-Modified-Since.
+	require.Equal(t, http.StatusOK, get("/quotes/last?pair=EUR/USD",
+		"If-None-Match", `"other"`, "If-Modified-Since", "Thu, 02 Jan 2025 03:04:05 GMT").Code)
+
+	// A new price changes the ETag even within the same second.
+	qr.store["EUR/USD"] = domain.Quote{Pair: "EUR/USD", Price: 1.2, UpdatedAt: ts}
+	rec = get("/quotes/last?pair=EUR/USD", "If-None-Match", etag)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotEqual(t, etag, rec.Header().Get("ETag"))
+
+	rec = get("/quotes/updates/u1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+	etag = rec.Header().Get("ETag")
+	require.Equal(t, http.StatusNotModified, get("/quotes/updates/u1", "If-None-Match", etag).Code)
+	require.Empty(t, rec.Header().Get("Last-Modified"))
+	// Claiming a job leaves updated_at as it was; only the ETag notices.
+	j := ur.jobs["u1"]
+	j.Status = domain.QuoteUpdateStatusProcessing
+	ur.jobs["u1"] = j
+	require.Equal(t, http.StatusOK, get("/quotes/updates/u1", "If-None-Match", etag).Code)
+	require.Equal(t, http.StatusOK, get("/quotes/updates/u1", "If-Modified-Since", j.UpdatedAt.Add(time.Hour).Format(http.TimeFormat)).Code)
+
+	rec = get("/quotes/latest?pairs=EUR/USD")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, http.StatusNotModified, get("/quotes/latest?pairs=EUR/USD", "If-None-Match", rec.Header().Get("ETag")).Code)
+	require.Empty(t, rec.Header().Get("Last-Modified"))
+
+	// A pair that appears with an older quote changes the answer; If-Modified-Since from
+	// the earlier, newer quote must not hide that.
+	rec = get("/quotes/latest?pairs=EUR/USD,USD/MXN")
+	require.Equal(t, http.StatusOK, rec.Code)
+	qr.store["USD/MXN"] = domain.Quote{Pair: "USD/MXN", Price: 17.1, UpdatedAt: ts.Add(-time.Hour)}
+	require.Equal(t, http.StatusOK, get("/quotes/latest?pairs=EUR/USD,USD/MXN",
+		"If-None-Match", rec.Header().Get("ETag"), "If-Modified-Since", ts.Format(http.TimeFormat)).Code)
+	require.Equal(t, http.StatusOK, get("/quotes/latest?pairs=EUR/USD,USD/MXN", "If-Modified-Since", ts.Format(http.TimeFormat)).Code)
+}
+
+func TestParseCacheMaxAge(t *testing.T) {
+	got, err := ParseCacheMaxAge(" /quotes/last=5s, /quotes/updates/{id}=0s,")
+	require.NoError(t, err)
+	require.Equal(t, map[string]time.Duration{cacheRouteLastQuote: 5 * time.Second, cacheRouteQuoteUpdate: 0}, got)
+
+	for _, bad := range []string{"/quotes/last", "/nowhere=5s", "/quotes/last=soon", "/quotes/last=-1s"} {
+		_, err := ParseCacheMaxAge(bad)
+		require.Error(t, err, bad)
+	}
+}
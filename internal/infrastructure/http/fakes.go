@@ -3,6 +3,9 @@ package httpserver
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
+	"sort"
 	"time"
 
 	"fxrates-service/internal/application"
@@ -14,10 +17,15 @@ import (
 var _ application.QuoteRepo = (*fakeQuoteRepo)(nil)
 var _ application.UpdateJobRepo = (*fakeUpdateJobRepo)(nil)
 var _ application.RateProvider = (*fakeRateProvider)(nil)
+var _ application.QuarantineRepo = (*fakeQuarantineRepo)(nil)
+var _ application.UpdateBatchRepo = (*fakeUpdateBatchRepo)(nil)
+var _ application.AttemptRepo = (*fakeAttemptRepo)(nil)
+var _ application.QuoteFeed = (*fakeQuoteFeed)(nil)
 
 type fakeQuoteRepo struct {
-	mu    sync.RWMutex
-	store map[string]domain.Quote
+	mu      sync.RWMutex
+	store   map[string]domain.Quote
+	history []domain.QuoteHistory
 }
 
 func (f *fakeQuoteRepo) GetLast(_ context.Context, pair string) (domain.Quote, error) {
@@ -33,6 +41,18 @@ func (f *fakeQuoteRepo) GetLast(_ context.Context, pair string) (domain.Quote, e
 	return q, nil
 }
 
+func (f *fakeQuoteRepo) GetMany(_ context.Context, pairs []string) ([]domain.Quote, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var out []domain.Quote
+	for _, p := range pairs {
+		if q, ok := f.store[p]; ok {
+			out = append(out, q)
+		}
+	}
+	return out, nil
+}
+
 func (f *fakeQuoteRepo) Upsert(_ context.Context, q domain.Quote) error {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -43,10 +63,30 @@ func (f *fakeQuoteRepo) Upsert(_ context.Context, q domain.Quote) error {
 	return nil
 }
 
-func (f *fakeQuoteRepo) AppendHistory(_ context.Context, _ domain.QuoteHistory) error {
+func (f *fakeQuoteRepo) AppendHistory(_ context.Context, h domain.QuoteHistory) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	h.ID = int64(len(f.history) + 1)
+	f.history = append(f.history, h)
 	return nil
 }
 
+func (f *fakeQuoteRepo) HistorySince(_ context.Context, afterID int64, pairs []string, limit int) ([]domain.QuoteHistory, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var out []domain.QuoteHistory
+	for _, h := range f.history {
+		if h.ID <= afterID || (len(pairs) > 0 && !slices.Contains(pairs, string(h.Pair))) {
+			continue
+		}
+		if len(out) == limit {
+			break
+		}
+		out = append(out, h)
+	}
+	return out, nil
+}
+
 type fakeUpdateJobRepo struct {
 	mu   sync.RWMutex
 	jobs map[string]domain.QuoteUpdate
@@ -59,7 +99,8 @@ func (f *fakeUpdateJobRepo) CreateQueued(_ context.Context, pair string, _ *stri
 		f.jobs = map[string]domain.QuoteUpdate{}
 	}
 	id := "update-1"
-	f.jobs[id] = domain.QuoteUpdate{ID: id, Pair: domain.Pair(pair), Status: domain.QuoteUpdateStatusQueued, UpdatedAt: time.Now()}
+	now := time.Now()
+	f.jobs[id] = domain.QuoteUpdate{ID: id, Pair: domain.Pair(pair), Status: domain.QuoteUpdateStatusQueued, UpdatedAt: now, RequestedAt: now}
 	return id, nil
 }
 
@@ -123,6 +164,252 @@ func (f *fakeUpdateJobRepo) ClaimQueued(_ context.Context, limit int) ([]struct{
 	return out, nil
 }
 
+func (f *fakeUpdateJobRepo) ClaimByID(_ context.Context, id string) (bool, error) {
+	return f.transition(id, domain.QuoteUpdateStatusQueued, domain.QuoteUpdateStatusProcessing)
+}
+
+func (f *fakeUpdateJobRepo) CancelQueued(_ context.Context, id string) (bool, error) {
+	return f.transition(id, domain.QuoteUpdateStatusQueued, domain.QuoteUpdateStatusCanceled)
+}
+
+func (f *fakeUpdateJobRepo) RequeueFailed(_ context.Context, id string) (bool, error) {
+	return f.transition(id, domain.QuoteUpdateStatusFailed, domain.QuoteUpdateStatusQueued)
+}
+
+func (f *fakeUpdateJobRepo) transition(id string, from, to domain.QuoteUpdateStatus) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	j, ok := f.jobs[id]
+	if !ok {
+		return false, domain.ErrNotFound
+	}
+	if j.Status != from {
+		return false, nil
+	}
+	j.Status = to
+	if to == domain.QuoteUpdateStatusQueued {
+		j.Error = nil
+	}
+	f.jobs[id] = j
+	return true, nil
+}
+
+func (f *fakeUpdateJobRepo) List(_ context.Context, flt domain.QuoteUpdateFilter) ([]domain.QuoteUpdate, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var out []domain.QuoteUpdate
+	for _, j := range f.jobs {
+		if fakeJobMatches(j, flt, true) {
+			out = append(out, j)
+		}
+	}
+	sort.Slice(out, func(i, k int) bool {
+		if !out[i].RequestedAt.Equal(out[k].RequestedAt) {
+			return out[i].RequestedAt.After(out[k].RequestedAt)
+		}
+		return out[i].ID > out[k].ID
+	})
+	if flt.Limit > 0 && len(out) > flt.Limit {
+		out = out[:flt.Limit]
+	}
+	return out, nil
+}
+
+func (f *fakeUpdateJobRepo) CountByStatus(_ context.Context, flt domain.QuoteUpdateFilter) (map[domain.QuoteUpdateStatus]int64, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	out := map[domain.QuoteUpdateStatus]int64{}
+	for _, j := range f.jobs {
+		if fakeJobMatches(j, flt, false) {
+			out[j.Status]++
+		}
+	}
+	return out, nil
+}
+
+func fakeJobMatches(j domain.QuoteUpdate, f domain.QuoteUpdateFilter, withStatus bool) bool {
+	switch {
+	case withStatus && f.Status != "" && j.Status != f.Status:
+		return false
+	case f.Pair != "" && j.Pair != f.Pair:
+		return false
+	case !f.From.IsZero() && j.RequestedAt.Before(f.From):
+		return false
+	case !f.To.IsZero() && !j.RequestedAt.Before(f.To):
+		return false
+	case withStatus && f.After != nil:
+		a := f.After
+		return j.RequestedAt.Before(a.RequestedAt) || (j.RequestedAt.Equal(a.RequestedAt) && j.ID < a.ID)
+	}
+	return true
+}
+
+type fakeQuarantineRepo struct {
+	mu    sync.Mutex
+	items []domain.QuarantinedQuote
+}
+
+func (f *fakeQuarantineRepo) Add(_ context.Context, q domain.QuarantinedQuote) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	q.ID = int64(len(f.items) + 1)
+	q.InsertedAt = time.Now()
+	f.items = append(f.items, q)
+	return q.ID, nil
+}
+
+func (f *fakeQuarantineRepo) Get(_ context.Context, id int64) (domain.QuarantinedQuote, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, q := range f.items {
+		if q.ID == id {
+			return q, nil
+		}
+	}
+	return domain.QuarantinedQuote{}, domain.ErrNotFound
+}
+
+func (f *fakeQuarantineRepo) ListPending(_ context.Context, pair string, limit int) ([]domain.QuarantinedQuote, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var out []domain.QuarantinedQuote
+	for i := len(f.items) - 1; i >= 0 && len(out) < limit; i-- {
+		q := f.items[i]
+		if q.ReleasedAt == nil && (pair == "" || string(q.Pair) == pair) {
+			out = append(out, q)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeQuarantineRepo) MarkReleased(_ context.Context, id int64, at time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.items {
+		if f.items[i].ID == id {
+			if f.items[i].ReleasedAt != nil {
+				return false, nil
+			}
+			f.items[i].ReleasedAt = &at
+			return true, nil
+		}
+	}
+	return false, domain.ErrNotFound
+}
+
+// fakeUpdateBatchRepo keeps member jobs in the shared fakeUpdateJobRepo.
+type fakeUpdateBatchRepo struct {
+	jobs    *fakeUpdateJobRepo
+	mu      sync.Mutex
+	members map[string][]string
+}
+
+func (f *fakeUpdateBatchRepo) Create(context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.members == nil {
+		f.members = map[string][]string{}
+	}
+	id := fmt.Sprintf("batch-%d", len(f.members)+1)
+	f.members[id] = nil
+	return id, nil
+}
+
+func (f *fakeUpdateBatchRepo) AddQueued(_ context.Context, batchID, pair string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := fmt.Sprintf("%s-%d", batchID, len(f.members[batchID])+1)
+	f.jobs.mu.Lock()
+	if f.jobs.jobs == nil {
+		f.jobs.jobs = map[string]domain.QuoteUpdate{}
+	}
+	now := time.Now()
+	f.jobs.jobs[id] = domain.QuoteUpdate{ID: id, Pair: domain.Pair(pair), Status: domain.QuoteUpdateStatusQueued, UpdatedAt: now, RequestedAt: now}
+	f.jobs.mu.Unlock()
+	f.members[batchID] = append(f.members[batchID], id)
+	return id, nil
+}
+
+func (f *fakeUpdateBatchRepo) Get(ctx context.Context, id string) (domain.QuoteUpdateBatch, error) {
+	f.mu.Lock()
+	ids, ok := f.members[id]
+	f.mu.Unlock()
+	if !ok {
+		return domain.QuoteUpdateBatch{}, domain.ErrNotFound
+	}
+	b := domain.QuoteUpdateBatch{ID: id}
+	for _, uid := range ids {
+		u, err := f.jobs.GetByID(ctx, uid)
+		if err != nil {
+			return domain.QuoteUpdateBatch{}, err
+		}
+		b.Updates = append(b.Updates, u)
+	}
+	sort.Slice(b.Updates, func(i, j int) bool { return b.Updates[i].Pair < b.Updates[j].Pair })
+	return b, nil
+}
+
+type fakeAttemptRepo struct {
+	mu       sync.Mutex
+	attempts map[string][]domain.QuoteUpdateAttempt
+}
+
+func (f *fakeAttemptRepo) Start(_ context.Context, updateID, worker string, at time.Time) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.attempts == nil {
+		f.attempts = map[string][]domain.QuoteUpdateAttempt{}
+	}
+	n := len(f.attempts[updateID]) + 1
+	f.attempts[updateID] = append(f.attempts[updateID], domain.QuoteUpdateAttempt{Number: n, Worker: worker, StartedAt: at})
+	return n, nil
+}
+
+func (f *fakeAttemptRepo) Finish(_ context.Context, updateID string, number int, at time.Time, errMsg *string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	as := f.attempts[updateID]
+	if number < 1 || number > len(as) {
+		return domain.ErrNotFound
+	}
+	as[number-1].FinishedAt = &at
+	as[number-1].Error = errMsg
+	return nil
+}
+
+func (f *fakeAttemptRepo) List(_ context.Context, updateID string) ([]domain.QuoteUpdateAttempt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]domain.QuoteUpdateAttempt(nil), f.attempts[updateID]...), nil
+}
+
+type fakeQuoteFeed struct {
+	mu   sync.Mutex
+	subs []chan domain.QuoteHistory
+}
+
+func (f *fakeQuoteFeed) Subscribe() (<-chan domain.QuoteHistory, func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan domain.QuoteHistory, 16)
+	f.subs = append(f.subs, ch)
+	return ch, func() {}
+}
+
+func (f *fakeQuoteFeed) subscribers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subs)
+}
+
+func (f *fakeQuoteFeed) publish(h domain.QuoteHistory) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, ch := range f.subs {
+		ch <- h
+	}
+}
+
 type fakeRateProvider struct{}
 
 func (fakeRateProvider) Get(_ context.Context, pair string) (domain.Quote, error) {
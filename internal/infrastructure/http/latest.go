@@ -0,0 +1,70 @@
+package httpserver
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/http/openapi"
+
+	"go.uber.org/zap"
+)
+
+// maxLatestPairs bounds the pairs parameter so a single call stays one cheap query.
+const maxLatestPairs = 100
+
+func (s *Server) GetLatestQuotes(w http.ResponseWriter, r *http.Request, params openapi.GetLatestQuotesParams) {
+	log := loggerForRequest(r)
+	requested := splitPairs(params.Pairs)
+	if len(requested) > maxLatestPairs {
+		log.Warn("get_latest_quotes.too_many_pairs", zap.Int("count", len(requested)))
+		writeProblem(w, r, problemValidationFailed, "", fieldError("pairs", fmt.Sprintf("at most %d pairs", maxLatestPairs)))
+		return
+	}
+	resp := openapi.LatestQuotes{Quotes: []openapi.LastQuote{}, Errors: []openapi.PairError{}}
+	var valid []string
+	for _, p := range requested {
+		if domain.ValidatePair(p) {
+			valid = append(valid, p)
+		} else {
+			resp.Errors = append(resp.Errors, openapi.PairError{Pair: p, Reason: openapi.PairErrorReasonInvalid})
+		}
+	}
+	// Every requested pair was invalid: report them rather than falling back to all pairs.
+	if len(requested) > 0 && len(valid) == 0 {
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+	quotes, missing, err := s.svc.GetLatestQuotes(r.Context(), valid)
+	if err != nil {
+		logRequestError(r, "get latest quotes failed", err)
+		writeProblem(w, r, problemInternal, "")
+		return
+	}
+	for _, q := range quotes {
+		resp.Quotes = append(resp.Quotes, toLastQuote(q))
+	}
+	for _, p := range missing {
+		resp.Errors = append(resp.Errors, openapi.PairError{Pair: p, Reason: openapi.PairErrorReasonNotFound})
+	}
+	log.Info("get_latest_quotes.success", zap.Int("found", len(resp.Quotes)), zap.Int("errors", len(resp.Errors)))
+	// No Last-Modified: a pair that shows up with an older quote than the rest changes the
+	// body without moving the newest timestamp, so only the ETag validates reliably.
+	s.writeCacheableJSON(w, r, cacheRouteLatest, resp, time.Time{})
+}
+
+// splitPairs parses a comma-separated pairs parameter, skipping blank entries.
+func splitPairs(param *string) []string {
+	if param == nil {
+		return nil
+	}
+	var out []string
+	for _, p := range strings.Split(*param, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
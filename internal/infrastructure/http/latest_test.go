@@ -0,0 +1,70 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+
+	"github.com/stretchr/testify/require"
+)
+
+type latestResp struct {
+	Quotes []struct {
+		Pair  string  `json:"pair"`
+		Price float64 `json:"price"`
+	} `json:"quotes"`
+	Errors []struct {
+		Pair   string `json:"pair"`
+		Reason string `json:"reason"`
+	} `json:"errors"`
+}
+
+func newLatestRouter() http.Handler {
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	qr := &fakeQuoteRepo{store: map[string]domain.Quote{
+		"EUR/USD": {Pair: "EUR/USD", Price: 1.1, UpdatedAt: ts},
+		"USD/MXN": {Pair: "USD/MXN", Price: 17.2, UpdatedAt: ts},
+	}}
+	svc := application.NewService(qr, &fakeUpdateJobRepo{}, fakeRateProvider{}, redisstore.NoopIdempotency{})
+	return NewRouter(NewServer(svc))
+}
+
+func getLatest(t *testing.T, h http.Handler, url string) latestResp {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var out latestResp
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	return out
+}
+
+func TestGetLatestQuotes_MixedPairs(t *testing.T) {
+	out := getLatest(t, newLatestRouter(), "/quotes/latest?pairs=USD/MXN,EUR/MXN,bogus,EUR/USD")
+	require.Len(t, out.Quotes, 2)
+	require.Equal(t, "USD/MXN", out.Quotes[0].Pair)
+	require.Equal(t, "EUR/USD", out.Quotes[1].Pair)
+	require.Len(t, out.Errors, 2)
+	require.Equal(t, "bogus", out.Errors[0].Pair)
+	require.Equal(t, "invalid", out.Errors[0].Reason)
+	require.Equal(t, "EUR/MXN", out.Errors[1].Pair)
+	require.Equal(t, "not_found", out.Errors[1].Reason)
+}
+
+func TestGetLatestQuotes_DefaultsToSupportedPairs(t *testing.T) {
+	out := getLatest(t, newLatestRouter(), "/quotes/latest")
+	require.Len(t, out.Quotes, 2)
+	require.Len(t, out.Errors, len(domain.SupportedPairs())-2)
+}
+
+func TestGetLatestQuotes_OnlyInvalidPairs(t *testing.T) {
+	out := getLatest(t, newLatestRouter(), "/quotes/latest?pairs=XXX")
+	require.Empty(t, out.Quotes)
+	require.Len(t, out.Errors, 1)
+}
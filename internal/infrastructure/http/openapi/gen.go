@@ -12,18 +12,81 @@ import (
 	"github.com/oapi-codegen/runtime"
 )
 
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for ListQuoteUpdatesParamsStatus.
+const (
+	ListQuoteUpdatesParamsStatusCanceled   ListQuoteUpdatesParamsStatus = "canceled"
+	ListQuoteUpdatesParamsStatusDone       ListQuoteUpdatesParamsStatus = "done"
+	ListQuoteUpdatesParamsStatusFailed     ListQuoteUpdatesParamsStatus = "failed"
+	ListQuoteUpdatesParamsStatusProcessing ListQuoteUpdatesParamsStatus = "processing"
+	ListQuoteUpdatesParamsStatusQueued     ListQuoteUpdatesParamsStatus = "queued"
+)
+
+// Defines values for PairErrorReason.
+const (
+	PairErrorReasonInvalid  PairErrorReason = "invalid"
+	PairErrorReasonNotFound PairErrorReason = "not_found"
+)
+
+// Defines values for QuarantinedQuoteReason.
+const (
+	DeviationExceeded QuarantinedQuoteReason = "deviation_exceeded"
+	NonPositivePrice  QuarantinedQuoteReason = "non_positive_price"
+	OutOfOrder        QuarantinedQuoteReason = "out_of_order"
+)
+
+// Defines values for QuoteUpdateBatchStatus.
+const (
+	QuoteUpdateBatchStatusDone    QuoteUpdateBatchStatus = "done"
+	QuoteUpdateBatchStatusFailed  QuoteUpdateBatchStatus = "failed"
+	QuoteUpdateBatchStatusPartial QuoteUpdateBatchStatus = "partial"
+	QuoteUpdateBatchStatusPending QuoteUpdateBatchStatus = "pending"
+)
+
 // Defines values for QuoteUpdateDetailsStatus.
 const (
-	Done       QuoteUpdateDetailsStatus = "done"
-	Failed     QuoteUpdateDetailsStatus = "failed"
-	Processing QuoteUpdateDetailsStatus = "processing"
-	Queued     QuoteUpdateDetailsStatus = "queued"
+	QuoteUpdateDetailsStatusCanceled   QuoteUpdateDetailsStatus = "canceled"
+	QuoteUpdateDetailsStatusDone       QuoteUpdateDetailsStatus = "done"
+	QuoteUpdateDetailsStatusFailed     QuoteUpdateDetailsStatus = "failed"
+	QuoteUpdateDetailsStatusProcessing QuoteUpdateDetailsStatus = "processing"
+	QuoteUpdateDetailsStatusQueued     QuoteUpdateDetailsStatus = "queued"
 )
 
-// Error defines model for Error.
+// Error RFC 7807 problem details, served as application/problem+json
 type Error struct {
-	Code    int32  `json:"code"`
-	Message string `json:"message"`
+	// Detail Explanation specific to this occurrence
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Field-level details of validation problems
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Path of the request that failed
+	Instance string `json:"instance"`
+
+	// RequestId X-Request-ID of the request, for finding it in the logs
+	RequestId *string `json:"request_id,omitempty"`
+
+	// Status HTTP status code
+	Status int32 `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type Stable problem type, urn:fxrates:problem:<name>
+	Type string `json:"type"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Detail What is wrong with the field
+	Detail string `json:"detail"`
+
+	// Field Body property, query parameter or header the problem is about
+	Field string `json:"field"`
 }
 
 // LastQuote defines model for LastQuote.
@@ -38,8 +101,155 @@ type LastQuote struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// LatestQuotes defines model for LatestQuotes.
+type LatestQuotes struct {
+	// Errors Requested pairs without a quote
+	Errors []PairError `json:"errors"`
+
+	// Quotes Last quotes of the requested pairs that have one
+	Quotes []LastQuote `json:"quotes"`
+}
+
+// PairError defines model for PairError.
+type PairError struct {
+	// Pair Requested currency pair
+	Pair string `json:"pair"`
+
+	// Reason Why no quote is returned for the pair
+	Reason PairErrorReason `json:"reason"`
+}
+
+// PairErrorReason Why no quote is returned for the pair
+type PairErrorReason string
+
+// ProviderStatus defines model for ProviderStatus.
+type ProviderStatus struct {
+	// AvgLatencyMs Exponentially weighted average call latency in milliseconds
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+
+	// Calls Recorded calls since the statistics were created
+	Calls int64 `json:"calls"`
+
+	// Failures Recorded failed calls
+	Failures int64 `json:"failures"`
+
+	// LastError Error class of the last failure
+	LastError *string `json:"last_error"`
+
+	// LastFailureAt Time of the last failed call
+	LastFailureAt *time.Time `json:"last_failure_at"`
+
+	// LastSuccessAt Time of the last successful call
+	LastSuccessAt *time.Time `json:"last_success_at"`
+
+	// Provider Provider name
+	Provider string `json:"provider"`
+
+	// Score Health score in [0,1] used for provider selection
+	Score float64 `json:"score"`
+
+	// SuccessRate Exponentially weighted success rate in [0,1]
+	SuccessRate float64 `json:"success_rate"`
+}
+
+// ProviderStatusList defines model for ProviderStatusList.
+type ProviderStatusList struct {
+	Items []ProviderStatus `json:"items"`
+}
+
+// QuarantinedQuote defines model for QuarantinedQuote.
+type QuarantinedQuote struct {
+	// Detail Human-readable explanation of the rejection
+	Detail string `json:"detail"`
+
+	// Id Quarantine entry identifier
+	Id int64 `json:"id"`
+
+	// InsertedAt When the quote was quarantined
+	InsertedAt time.Time `json:"inserted_at"`
+
+	// Pair Currency pair
+	Pair string `json:"pair"`
+
+	// Price Rejected quote price
+	Price float64 `json:"price"`
+
+	// QuotedAt Provider timestamp of the quote
+	QuotedAt time.Time `json:"quoted_at"`
+
+	// Reason Why the quote was rejected
+	Reason QuarantinedQuoteReason `json:"reason"`
+
+	// ReleasedAt When the quote was released (if it was)
+	ReleasedAt *time.Time `json:"released_at"`
+
+	// Source Processing path that fetched the quote
+	Source string `json:"source"`
+
+	// UpdateId Update request that fetched the quote
+	UpdateId *string `json:"update_id"`
+}
+
+// QuarantinedQuoteReason Why the quote was rejected
+type QuarantinedQuoteReason string
+
+// QuarantinedQuoteList defines model for QuarantinedQuoteList.
+type QuarantinedQuoteList struct {
+	Items []QuarantinedQuote `json:"items"`
+}
+
+// QuoteUpdateAttempt defines model for QuoteUpdateAttempt.
+type QuoteUpdateAttempt struct {
+	// Error Error message (if the attempt failed)
+	Error      *string    `json:"error"`
+	FinishedAt *time.Time `json:"finished_at"`
+
+	// Number Attempt number, starting at 1
+	Number    int       `json:"number"`
+	StartedAt time.Time `json:"started_at"`
+
+	// Worker Process that ran the attempt (WORKER_ID, host:pid by default)
+	Worker string `json:"worker"`
+}
+
+// QuoteUpdateBatch defines model for QuoteUpdateBatch.
+type QuoteUpdateBatch struct {
+	// BatchId Unique identifier for the batch
+	BatchId string `json:"batch_id"`
+
+	// CreatedAt When the batch was requested
+	CreatedAt time.Time `json:"created_at"`
+
+	// Status Aggregated status of the member updates
+	Status QuoteUpdateBatchStatus `json:"status"`
+
+	// Updates Member updates ordered by pair
+	Updates []QuoteUpdateDetails `json:"updates"`
+}
+
+// QuoteUpdateBatchStatus Aggregated status of the member updates
+type QuoteUpdateBatchStatus string
+
+// QuoteUpdateBatchRequest defines model for QuoteUpdateBatchRequest.
+type QuoteUpdateBatchRequest struct {
+	// Pairs Currency pairs to update; duplicates are ignored
+	Pairs []string `json:"pairs"`
+}
+
+// QuoteUpdateBatchResponse defines model for QuoteUpdateBatchResponse.
+type QuoteUpdateBatchResponse struct {
+	// BatchId Unique identifier for the batch
+	BatchId string `json:"batch_id"`
+
+	// UpdateIds Identifiers of the queued member updates
+	UpdateIds []string `json:"update_ids"`
+}
+
 // QuoteUpdateDetails defines model for QuoteUpdateDetails.
 type QuoteUpdateDetails struct {
+	// Attempts Processing attempts, oldest first
+	Attempts *[]QuoteUpdateAttempt `json:"attempts,omitempty"`
+
 	// Error Error message (if status is failed)
 	Error *string `json:"error"`
 
@@ -62,6 +272,15 @@ type QuoteUpdateDetails struct {
 // QuoteUpdateDetailsStatus Status of the update request
 type QuoteUpdateDetailsStatus string
 
+// QuoteUpdateList defines model for QuoteUpdateList.
+type QuoteUpdateList struct {
+	Counts QuoteUpdateStatusCounts `json:"counts"`
+	Items  []QuoteUpdateDetails    `json:"items"`
+
+	// NextCursor Cursor for the next page; absent on the last page
+	NextCursor *string `json:"next_cursor"`
+}
+
 // QuoteUpdateRequest defines model for QuoteUpdateRequest.
 type QuoteUpdateRequest struct {
 	// Pair Currency pair (e.g., USD/EUR)
@@ -74,65 +293,236 @@ type QuoteUpdateResponse struct {
 	UpdateId string `json:"update_id"`
 }
 
+// QuoteUpdateStatusCounts Jobs matching the filter, ignoring status and pagination
+type QuoteUpdateStatusCounts struct {
+	Canceled   int64 `json:"canceled"`
+	Done       int64 `json:"done"`
+	Failed     int64 `json:"failed"`
+	Processing int64 `json:"processing"`
+	Queued     int64 `json:"queued"`
+}
+
 // BadRequest defines model for BadRequest.
 type BadRequest = Error
 
 // Conflict defines model for Conflict.
 type Conflict = Error
 
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
 // InternalError defines model for InternalError.
 type InternalError = Error
 
 // NotFound defines model for NotFound.
 type NotFound = Error
 
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = Error
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// ListQuarantinedQuotesParams defines parameters for ListQuarantinedQuotes.
+type ListQuarantinedQuotesParams struct {
+	// Pair Only return entries for this currency pair
+	Pair *string `form:"pair,omitempty" json:"pair,omitempty"`
+
+	// Limit Maximum number of entries to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // GetLastQuoteParams defines parameters for GetLastQuote.
 type GetLastQuoteParams struct {
 	// Pair Currency pair (e.g., USD/EUR)
 	Pair string `form:"pair" json:"pair"`
 }
 
+// GetLatestQuotesParams defines parameters for GetLatestQuotes.
+type GetLatestQuotesParams struct {
+	// Pairs Comma-separated currency pairs; all supported pairs when omitted
+	Pairs *string `form:"pairs,omitempty" json:"pairs,omitempty"`
+}
+
+// StreamQuotesParams defines parameters for StreamQuotes.
+type StreamQuotesParams struct {
+	// Pairs Comma-separated currency pairs; all pairs when omitted
+	Pairs *string `form:"pairs,omitempty" json:"pairs,omitempty"`
+
+	// LastEventID Id of the last event received; changes after it are replayed first
+	LastEventID *int64 `json:"Last-Event-ID,omitempty"`
+}
+
+// ListQuoteUpdatesParams defines parameters for ListQuoteUpdates.
+type ListQuoteUpdatesParams struct {
+	// Status Only return jobs in this status
+	Status *ListQuoteUpdatesParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Pair Only return jobs for this currency pair
+	Pair *string `form:"pair,omitempty" json:"pair,omitempty"`
+
+	// From Only return jobs requested at or after this time
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Only return jobs requested before this time
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Limit Maximum number of jobs to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor of the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListQuoteUpdatesParamsStatus defines parameters for ListQuoteUpdates.
+type ListQuoteUpdatesParamsStatus string
+
 // RequestQuoteUpdateParams defines parameters for RequestQuoteUpdate.
 type RequestQuoteUpdateParams struct {
 	// XIdempotencyKey Idempotency key for the request
 	XIdempotencyKey string `json:"X-Idempotency-Key"`
 }
 
+// RequestQuoteUpdateBatchParams defines parameters for RequestQuoteUpdateBatch.
+type RequestQuoteUpdateBatchParams struct {
+	// XIdempotencyKey Idempotency key for the whole batch
+	XIdempotencyKey string `json:"X-Idempotency-Key"`
+}
+
+// GetQuoteUpdateParams defines parameters for GetQuoteUpdate.
+type GetQuoteUpdateParams struct {
+	// Wait Maximum time to wait for a terminal status, as a duration (capped at 30s)
+	Wait *string `form:"wait,omitempty" json:"wait,omitempty"`
+}
+
 // RequestQuoteUpdateJSONRequestBody defines body for RequestQuoteUpdate for application/json ContentType.
 type RequestQuoteUpdateJSONRequestBody = QuoteUpdateRequest
 
+// RequestQuoteUpdateBatchJSONRequestBody defines body for RequestQuoteUpdateBatch for application/json ContentType.
+type RequestQuoteUpdateBatchJSONRequestBody = QuoteUpdateBatchRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List quarantined quotes awaiting release
+	// (GET /admin/quarantine)
+	ListQuarantinedQuotes(w http.ResponseWriter, r *http.Request, params ListQuarantinedQuotesParams)
+	// Release a quarantined quote
+	// (POST /admin/quarantine/{id}/release)
+	ReleaseQuarantinedQuote(w http.ResponseWriter, r *http.Request, id int64)
+	// Get upstream provider health
+	// (GET /providers/status)
+	GetProviderStatus(w http.ResponseWriter, r *http.Request)
 	// Get last quote for a currency pair
 	// (GET /quotes/last)
 	GetLastQuote(w http.ResponseWriter, r *http.Request, params GetLastQuoteParams)
+	// Get last quotes for several currency pairs
+	// (GET /quotes/latest)
+	GetLatestQuotes(w http.ResponseWriter, r *http.Request, params GetLatestQuotesParams)
+	// Stream quote changes
+	// (GET /quotes/stream)
+	StreamQuotes(w http.ResponseWriter, r *http.Request, params StreamQuotesParams)
+	// List quote update jobs
+	// (GET /quotes/updates)
+	ListQuoteUpdates(w http.ResponseWriter, r *http.Request, params ListQuoteUpdatesParams)
 	// Request a quote update
 	// (POST /quotes/updates)
 	RequestQuoteUpdate(w http.ResponseWriter, r *http.Request, params RequestQuoteUpdateParams)
+	// Request updates for several pairs
+	// (POST /quotes/updates/batch)
+	RequestQuoteUpdateBatch(w http.ResponseWriter, r *http.Request, params RequestQuoteUpdateBatchParams)
+	// Get batch update status
+	// (GET /quotes/updates/batch/{id})
+	GetQuoteUpdateBatch(w http.ResponseWriter, r *http.Request, id string)
+	// Cancel a queued quote update
+	// (DELETE /quotes/updates/{id})
+	CancelQuoteUpdate(w http.ResponseWriter, r *http.Request, id string)
 	// Get quote update status
 	// (GET /quotes/updates/{id})
-	GetQuoteUpdate(w http.ResponseWriter, r *http.Request, id string)
+	GetQuoteUpdate(w http.ResponseWriter, r *http.Request, id string, params GetQuoteUpdateParams)
+	// Retry a failed quote update
+	// (POST /quotes/updates/{id}/retry)
+	RetryQuoteUpdate(w http.ResponseWriter, r *http.Request, id string)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
+// List quarantined quotes awaiting release
+// (GET /admin/quarantine)
+func (_ Unimplemented) ListQuarantinedQuotes(w http.ResponseWriter, r *http.Request, params ListQuarantinedQuotesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Release a quarantined quote
+// (POST /admin/quarantine/{id}/release)
+func (_ Unimplemented) ReleaseQuarantinedQuote(w http.ResponseWriter, r *http.Request, id int64) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get upstream provider health
+// (GET /providers/status)
+func (_ Unimplemented) GetProviderStatus(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get last quote for a currency pair
 // (GET /quotes/last)
 func (_ Unimplemented) GetLastQuote(w http.ResponseWriter, r *http.Request, params GetLastQuoteParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Get last quotes for several currency pairs
+// (GET /quotes/latest)
+func (_ Unimplemented) GetLatestQuotes(w http.ResponseWriter, r *http.Request, params GetLatestQuotesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Stream quote changes
+// (GET /quotes/stream)
+func (_ Unimplemented) StreamQuotes(w http.ResponseWriter, r *http.Request, params StreamQuotesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List quote update jobs
+// (GET /quotes/updates)
+func (_ Unimplemented) ListQuoteUpdates(w http.ResponseWriter, r *http.Request, params ListQuoteUpdatesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Request a quote update
 // (POST /quotes/updates)
 func (_ Unimplemented) RequestQuoteUpdate(w http.ResponseWriter, r *http.Request, params RequestQuoteUpdateParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Request updates for several pairs
+// (POST /quotes/updates/batch)
+func (_ Unimplemented) RequestQuoteUpdateBatch(w http.ResponseWriter, r *http.Request, params RequestQuoteUpdateBatchParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get batch update status
+// (GET /quotes/updates/batch/{id})
+func (_ Unimplemented) GetQuoteUpdateBatch(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel a queued quote update
+// (DELETE /quotes/updates/{id})
+func (_ Unimplemented) CancelQuoteUpdate(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get quote update status
 // (GET /quotes/updates/{id})
-func (_ Unimplemented) GetQuoteUpdate(w http.ResponseWriter, r *http.Request, id string) {
+func (_ Unimplemented) GetQuoteUpdate(w http.ResponseWriter, r *http.Request, id string, params GetQuoteUpdateParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Retry a failed quote update
+// (POST /quotes/updates/{id}/retry)
+func (_ Unimplemented) RetryQuoteUpdate(w http.ResponseWriter, r *http.Request, id string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -145,6 +535,80 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// ListQuarantinedQuotes operation middleware
+func (siw *ServerInterfaceWrapper) ListQuarantinedQuotes(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListQuarantinedQuotesParams
+
+	// ------------- Optional query parameter "pair" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pair", r.URL.Query(), &params.Pair)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pair", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListQuarantinedQuotes(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReleaseQuarantinedQuote operation middleware
+func (siw *ServerInterfaceWrapper) ReleaseQuarantinedQuote(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReleaseQuarantinedQuote(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetProviderStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetProviderStatus(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProviderStatus(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetLastQuote operation middleware
 func (siw *ServerInterfaceWrapper) GetLastQuote(w http.ResponseWriter, r *http.Request) {
 
@@ -179,6 +643,148 @@ func (siw *ServerInterfaceWrapper) GetLastQuote(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// GetLatestQuotes operation middleware
+func (siw *ServerInterfaceWrapper) GetLatestQuotes(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetLatestQuotesParams
+
+	// ------------- Optional query parameter "pairs" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pairs", r.URL.Query(), &params.Pairs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pairs", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLatestQuotes(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StreamQuotes operation middleware
+func (siw *ServerInterfaceWrapper) StreamQuotes(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamQuotesParams
+
+	// ------------- Optional query parameter "pairs" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pairs", r.URL.Query(), &params.Pairs)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pairs", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Last-Event-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Last-Event-ID")]; found {
+		var LastEventID int64
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Last-Event-ID", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Last-Event-ID", valueList[0], &LastEventID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Last-Event-ID", Err: err})
+			return
+		}
+
+		params.LastEventID = &LastEventID
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StreamQuotes(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListQuoteUpdates operation middleware
+func (siw *ServerInterfaceWrapper) ListQuoteUpdates(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListQuoteUpdatesParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "pair" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "pair", r.URL.Query(), &params.Pair)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "pair", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListQuoteUpdates(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // RequestQuoteUpdate operation middleware
 func (siw *ServerInterfaceWrapper) RequestQuoteUpdate(w http.ResponseWriter, r *http.Request) {
 
@@ -223,6 +829,100 @@ func (siw *ServerInterfaceWrapper) RequestQuoteUpdate(w http.ResponseWriter, r *
 	handler.ServeHTTP(w, r)
 }
 
+// RequestQuoteUpdateBatch operation middleware
+func (siw *ServerInterfaceWrapper) RequestQuoteUpdateBatch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RequestQuoteUpdateBatchParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Idempotency-Key")]; found {
+		var XIdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Idempotency-Key", valueList[0], &XIdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Idempotency-Key", Err: err})
+			return
+		}
+
+		params.XIdempotencyKey = XIdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RequestQuoteUpdateBatch(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetQuoteUpdateBatch operation middleware
+func (siw *ServerInterfaceWrapper) GetQuoteUpdateBatch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetQuoteUpdateBatch(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelQuoteUpdate operation middleware
+func (siw *ServerInterfaceWrapper) CancelQuoteUpdate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelQuoteUpdate(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetQuoteUpdate operation middleware
 func (siw *ServerInterfaceWrapper) GetQuoteUpdate(w http.ResponseWriter, r *http.Request) {
 
@@ -237,8 +937,44 @@ func (siw *ServerInterfaceWrapper) GetQuoteUpdate(w http.ResponseWriter, r *http
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetQuoteUpdateParams
+
+	// ------------- Optional query parameter "wait" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wait", r.URL.Query(), &params.Wait)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "wait", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetQuoteUpdate(w, r, id)
+		siw.Handler.GetQuoteUpdate(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RetryQuoteUpdate operation middleware
+func (siw *ServerInterfaceWrapper) RetryQuoteUpdate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RetryQuoteUpdate(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -361,15 +1097,45 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/quarantine", wrapper.ListQuarantinedQuotes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/quarantine/{id}/release", wrapper.ReleaseQuarantinedQuote)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/providers/status", wrapper.GetProviderStatus)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/quotes/last", wrapper.GetLastQuote)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/quotes/latest", wrapper.GetLatestQuotes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/quotes/stream", wrapper.StreamQuotes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/quotes/updates", wrapper.ListQuoteUpdates)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/quotes/updates", wrapper.RequestQuoteUpdate)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/quotes/updates/batch", wrapper.RequestQuoteUpdateBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/quotes/updates/batch/{id}", wrapper.GetQuoteUpdateBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/quotes/updates/{id}", wrapper.CancelQuoteUpdate)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/quotes/updates/{id}", wrapper.GetQuoteUpdate)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/quotes/updates/{id}/retry", wrapper.RetryQuoteUpdate)
+	})
 
 	return r
 }
@@ -0,0 +1,141 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/http/openapi"
+)
+
+// problemType is the stable, machine-readable kind of an error response. Clients match
+// on it; titles and details are for humans and may change.
+type problemType string
+
+const (
+	problemMalformedBody        problemType = "malformed_body"
+	problemValidationFailed     problemType = "validation_failed"
+	problemInvalidPair          problemType = "invalid_pair"
+	problemUnsupportedCurrency  problemType = "unsupported_currency"
+	problemIdempotencyConflict  problemType = "idempotency_conflict"
+	problemIdempotencyMismatch  problemType = "idempotency_mismatch"
+	problemNotFound             problemType = "not_found"
+	problemMethodNotAllowed     problemType = "method_not_allowed"
+	problemStateConflict        problemType = "state_conflict"
+	problemUnauthenticated      problemType = "unauthenticated"
+	problemForbidden            problemType = "forbidden"
+	problemRateLimited          problemType = "rate_limited"
+	problemDispatchUnavailable  problemType = "dispatch_unavailable"
+	problemStreamingUnavailable problemType = "streaming_unavailable"
+	problemNotReady             problemType = "not_ready"
+	problemInternal             problemType = "internal_error"
+)
+
+// problemTypePrefix turns a problemType into the URI RFC 7807 asks for.
+const problemTypePrefix = "urn:fxrates:problem:"
+
+const problemContentType = "application/problem+json"
+
+var problemTypes = map[problemType]struct {
+	status int
+	title  string
+}{
+	problemMalformedBody:        {http.StatusBadRequest, "Request body is not valid JSON"},
+	problemValidationFailed:     {http.StatusBadRequest, "Request failed validation"},
+	problemInvalidPair:          {http.StatusBadRequest, "Invalid currency pair"},
+	problemUnsupportedCurrency:  {http.StatusBadRequest, "Unsupported currency"},
+	problemIdempotencyConflict:  {http.StatusConflict, "Request with this idempotency key is in progress"},
+	problemIdempotencyMismatch:  {http.StatusUnprocessableEntity, "Idempotency key reused with a different request"},
+	problemNotFound:             {http.StatusNotFound, "Resource not found"},
+	problemMethodNotAllowed:     {http.StatusMethodNotAllowed, "Method not allowed"},
+	problemStateConflict:        {http.StatusConflict, "Resource is not in a state that allows this request"},
+	problemUnauthenticated:      {http.StatusUnauthorized, "Missing or invalid API key"},
+	problemForbidden:            {http.StatusForbidden, "API key lacks the required scope"},
+	problemRateLimited:          {http.StatusTooManyRequests, "Rate limit exceeded"},
+	problemDispatchUnavailable:  {http.StatusServiceUnavailable, "Update could not be handed to a worker"},
+	problemStreamingUnavailable: {http.StatusServiceUnavailable, "Streaming is unavailable"},
+	problemNotReady:             {http.StatusServiceUnavailable, "Service is not ready"},
+	problemInternal:             {http.StatusInternalServerError, "Internal error"},
+}
+
+// fieldError points a validation problem at one request field: a body property, query
+// parameter or header.
+func fieldError(field, detail string) openapi.FieldError {
+	return openapi.FieldError{Field: field, Detail: detail}
+}
+
+// writeProblem answers with an RFC 7807 problem document carrying the request id, so a
+// failed call can be found in the logs.
+func writeProblem(w http.ResponseWriter, r *http.Request, typ problemType, detail string, fields ...openapi.FieldError) {
+	pt := problemTypes[typ]
+	p := openapi.Error{
+		Type:     problemTypePrefix + string(typ),
+		Title:    pt.title,
+		Status:   int32(pt.status),
+		Instance: r.URL.Path,
+	}
+	if detail != "" {
+		p.Detail = &detail
+	}
+	if rid, _ := r.Context().Value(requestIDKey).(string); rid != "" {
+		p.RequestId = &rid
+	}
+	if len(fields) > 0 {
+		p.Errors = &fields
+	}
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(pt.status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// writePairProblem rejects pair, read from field, as malformed or as using a currency
+// the service does not quote.
+func writePairProblem(w http.ResponseWriter, r *http.Request, field, pair string) {
+	typ, fe := pairProblem(field, pair)
+	writeProblem(w, r, typ, "", fe)
+}
+
+// pairProblem describes why pair, read from field, is not valid.
+func pairProblem(field, pair string) (problemType, openapi.FieldError) {
+	if errors.Is(domain.CheckPair(pair), domain.ErrUnsupportedCurrency) {
+		return problemUnsupportedCurrency,
+			fieldError(field, quoteValue(pair)+" uses a currency other than "+domain.SupportedCurrencyList())
+	}
+	return problemInvalidPair,
+		fieldError(field, "want two different currency codes as BASE/QUOTE, e.g. EUR/USD, got "+quoteValue(pair))
+}
+
+func quoteValue(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// bindingProblem describes the parameter errors reported by the generated router; other
+// errors get no field details.
+func bindingProblem(err error) []openapi.FieldError {
+	var (
+		required    *openapi.RequiredParamError
+		header      *openapi.RequiredHeaderError
+		format      *openapi.InvalidParamFormatError
+		unmarshal   *openapi.UnmarshalingParamError
+		tooMany     *openapi.TooManyValuesForParamError
+		cookieError *openapi.UnescapedCookieParamError
+	)
+	switch {
+	case errors.As(err, &required):
+		return []openapi.FieldError{fieldError(required.ParamName, "is required")}
+	case errors.As(err, &header):
+		return []openapi.FieldError{fieldError(header.ParamName, "is required")}
+	case errors.As(err, &format):
+		return []openapi.FieldError{fieldError(format.ParamName, "has an invalid format")}
+	case errors.As(err, &unmarshal):
+		return []openapi.FieldError{fieldError(unmarshal.ParamName, "has an invalid format")}
+	case errors.As(err, &tooMany):
+		return []openapi.FieldError{fieldError(tooMany.ParamName, "must be given once")}
+	case errors.As(err, &cookieError):
+		return []openapi.FieldError{fieldError(cookieError.ParamName, "has an invalid format")}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/infrastructure/http/openapi"
+
+	"github.com/stretchr/testify/require"
+)
+
+// requireProblem checks that rec holds a problem document of type typ with details for
+// exactly fields, and returns those details.
+func requireProblem(t *testing.T, rec *httptest.ResponseRecorder, typ problemType, fields ...string) []openapi.FieldError {
+	t.Helper()
+	require.Equal(t, problemContentType, rec.Header().Get("Content-Type"))
+	var p openapi.Error
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	require.Equal(t, problemTypePrefix+string(typ), p.Type)
+	require.Equal(t, problemTypes[typ].title, p.Title)
+	require.EqualValues(t, rec.Code, p.Status)
+	require.NotEmpty(t, p.Instance)
+	require.NotNil(t, p.RequestId)
+	require.Equal(t, rec.Header().Get("X-Request-ID"), *p.RequestId)
+	var got []string
+	if p.Errors != nil {
+		for _, fe := range *p.Errors {
+			got = append(got, fe.Field)
+			require.NotEmpty(t, fe.Detail)
+		}
+	}
+	require.Equal(t, fields, got)
+	if p.Errors == nil {
+		return nil
+	}
+	return *p.Errors
+}
+
+func TestProblemStatusesMatchTypes(t *testing.T) {
+	for typ, pt := range problemTypes {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/x", nil)
+		writeProblem(rec, req, typ, "")
+		require.Equal(t, pt.status, rec.Code, typ)
+		require.NotEmpty(t, pt.title, typ)
+	}
+}
+
+func TestProblems(t *testing.T) {
+	h := setup()
+	do := func(method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Request-ID", "req-1")
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+	idem := map[string]string{"X-Idempotency-Key": "k1"}
+
+	rec := do(http.MethodPost, "/quotes/updates", `{"pair":`, idem)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	requireProblem(t, rec, problemMalformedBody)
+
+	rec = do(http.MethodPost, "/quotes/updates", `{}`, idem)
+	requireProblem(t, rec, problemValidationFailed, "pair")
+
+	// The batch route binds its header through the generated router.
+	rec = do(http.MethodPost, "/quotes/updates/batch", `{"pairs":["EUR/USD"]}`, nil)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	requireProblem(t, rec, problemValidationFailed, "X-Idempotency-Key")
+
+	// Every bad pair of a batch is reported; a malformed one names the problem.
+	rec = do(http.MethodPost, "/quotes/updates/batch", `{"pairs":["GBP/USD","EUR/USD","eurusd"]}`, idem)
+	fes := requireProblem(t, rec, problemInvalidPair, "pairs[0]", "pairs[2]")
+	require.Contains(t, fes[0].Detail, "EUR, MXN, USD")
+
+	rec = do(http.MethodGet, "/quotes/updates?limit=0&status=bogus", "", nil)
+	requireProblem(t, rec, problemValidationFailed, "status")
+
+	rec = do(http.MethodGet, "/quotes/updates?limit=abc", "", nil)
+	requireProblem(t, rec, problemValidationFailed, "limit")
+
+	requireProblem(t, do(http.MethodGet, "/nowhere", "", nil), problemNotFound)
+	rec = do(http.MethodPut, "/quotes/last", "", nil)
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	requireProblem(t, rec, problemMethodNotAllowed)
+
+	rec = do(http.MethodGet, "/quotes/updates/nope", "", nil)
+	var p openapi.Error
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+	require.Equal(t, "/quotes/updates/nope", p.Instance)
+	require.Equal(t, "req-1", *p.RequestId)
+	require.Equal(t, "no update with id nope", *p.Detail)
+}
+
+func TestRequestQuoteUpdate_DispatchUnavailable(t *testing.T) {
+	svc, _, _, _ := NewInMemoryService()
+	srv := NewServer(svc)
+	srv.SetDispatcher(func(_ context.Context, _, _, _ string) error { return errors.New("queue full") })
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/quotes/updates", bytes.NewBufferString(`{"pair":"EUR/USD"}`))
+	req.Header.Set("X-Idempotency-Key", "k1")
+	NewRouter(srv).ServeHTTP(rec, req)
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	requireProblem(t, rec, problemDispatchUnavailable)
+}
+
+func TestRequestQuoteUpdate_DispatchUnavailableIsRetried(t *testing.T) {
+	qr, ur, rp := NewInMemoryRepos()
+	srv := NewServer(application.NewService(qr, ur, rp, &memIdem{}))
+	dispatched := 0
+	srv.SetDispatcher(func(context.Context, string, string, string) error {
+		dispatched++
+		if dispatched == 1 {
+			return errors.New("queue full")
+		}
+		return nil
+	})
+	h := NewRouter(srv)
+	post := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/quotes/updates", bytes.NewBufferString(`{"pair":"EUR/USD"}`))
+		req.Header.Set("X-Idempotency-Key", "k1")
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post()
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	requireProblem(t, rec, problemDispatchUnavailable)
+	rec = post()
+	require.Equal(t, http.StatusAccepted, rec.Code, "a retry after a failed dispatch dispatches again")
+	require.Empty(t, rec.Header().Get(replayedHeader))
+	require.Equal(t, 2, dispatched)
+}
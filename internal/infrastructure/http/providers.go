@@ -0,0 +1,38 @@
+package httpserver
+
+import (
+	"net/http"
+	"time"
+
+	"fxrates-service/internal/infrastructure/http/openapi"
+
+	"go.uber.org/zap"
+)
+
+func (s *Server) GetProviderStatus(w http.ResponseWriter, r *http.Request) {
+	stats, err := s.svc.ProviderStatus(r.Context())
+	if err != nil {
+		logRequestError(r, "get provider status failed", err)
+		writeProblem(w, r, problemInternal, "")
+		return
+	}
+	resp := openapi.ProviderStatusList{Items: make([]openapi.ProviderStatus, 0, len(stats))}
+	for _, h := range stats {
+		item := openapi.ProviderStatus{
+			Provider:      h.Provider,
+			Calls:         h.Calls,
+			Failures:      h.Failures,
+			SuccessRate:   h.SuccessRate,
+			AvgLatencyMs:  float64(h.AvgLatency) / float64(time.Millisecond),
+			Score:         h.Score(),
+			LastSuccessAt: h.LastSuccess,
+			LastFailureAt: h.LastFailure,
+		}
+		if h.LastError != "" {
+			item.LastError = &h.LastError
+		}
+		resp.Items = append(resp.Items, item)
+	}
+	loggerForRequest(r).Info("get_provider_status.success", zap.Int("count", len(stats)))
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,45 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/provider"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProviderStatus(t *testing.T) {
+	health := provider.NewMemoryHealth()
+	_ = health.Record(context.Background(), "ecb", domain.ProviderCall{Latency: 250 * time.Millisecond, At: time.Now()})
+	qr, ur, rp := NewInMemoryRepos()
+	svc := application.NewService(qr, ur, rp, redisstore.NoopIdempotency{}, application.WithProviderHealth(health))
+	h := NewRouter(NewServer(svc))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/providers/status", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct {
+		Items []struct {
+			Provider     string  `json:"provider"`
+			Calls        int64   `json:"calls"`
+			AvgLatencyMs float64 `json:"avg_latency_ms"`
+			Score        float64 `json:"score"`
+			LastError    *string `json:"last_error"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Items, 1)
+	require.Equal(t, "ecb", resp.Items[0].Provider)
+	require.EqualValues(t, 1, resp.Items[0].Calls)
+	require.InDelta(t, 250, resp.Items[0].AvgLatencyMs, 1e-9)
+	require.InDelta(t, 0.8, resp.Items[0].Score, 1e-9)
+	require.Nil(t, resp.Items[0].LastError)
+}
@@ -0,0 +1,85 @@
+package httpserver
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/http/openapi"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultQuarantineLimit = 50
+	maxQuarantineLimit     = 500
+)
+
+func (s *Server) ListQuarantinedQuotes(w http.ResponseWriter, r *http.Request, params openapi.ListQuarantinedQuotesParams) {
+	log := loggerForRequest(r)
+	pair := ""
+	if params.Pair != nil {
+		pair = *params.Pair
+		if !domain.ValidatePair(pair) {
+			log.Warn("list_quarantine.invalid_pair_format", zap.String("pair", pair))
+			writePairProblem(w, r, "pair", pair)
+			return
+		}
+	}
+	limit := defaultQuarantineLimit
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > maxQuarantineLimit {
+			writeProblem(w, r, problemValidationFailed, "", fieldError("limit", fmt.Sprintf("must be between 1 and %d", maxQuarantineLimit)))
+			return
+		}
+		limit = *params.Limit
+	}
+	items, err := s.svc.ListQuarantinedQuotes(r.Context(), pair, limit)
+	if err != nil {
+		logRequestError(r, "list quarantined quotes failed", err)
+		writeProblem(w, r, problemInternal, "")
+		return
+	}
+	resp := openapi.QuarantinedQuoteList{Items: make([]openapi.QuarantinedQuote, 0, len(items))}
+	for _, q := range items {
+		resp.Items = append(resp.Items, toQuarantinedQuote(q))
+	}
+	log.Info("list_quarantine.success", zap.Int("count", len(items)))
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) ReleaseQuarantinedQuote(w http.ResponseWriter, r *http.Request, id int64) {
+	log := loggerForRequest(r).With(zap.Int64("quarantine_id", id))
+	q, err := s.svc.ReleaseQuarantinedQuote(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeProblem(w, r, problemNotFound, fmt.Sprintf("no quarantined quote with id %d", id))
+		case errors.Is(err, application.ErrConflict):
+			writeProblem(w, r, problemStateConflict, "quote was already released")
+		default:
+			logRequestError(r, "release quarantined quote failed", err)
+			writeProblem(w, r, problemInternal, "")
+		}
+		return
+	}
+	log.Info("release_quarantine.success", zap.String("pair", string(q.Pair)))
+	writeJSON(w, http.StatusOK, toQuarantinedQuote(q))
+}
+
+func toQuarantinedQuote(q domain.QuarantinedQuote) openapi.QuarantinedQuote {
+	return openapi.QuarantinedQuote{
+		Id:         q.ID,
+		Pair:       string(q.Pair),
+		Price:      q.Price,
+		QuotedAt:   q.QuotedAt,
+		Source:     q.Source,
+		UpdateId:   q.UpdateID,
+		Reason:     openapi.QuarantinedQuoteReason(q.Reason),
+		Detail:     q.Detail,
+		InsertedAt: q.InsertedAt,
+		ReleasedAt: q.ReleasedAt,
+	}
+}
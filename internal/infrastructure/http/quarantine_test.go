@@ -0,0 +1,66 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuarantine_ListAndRelease(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	qr := &fakeQuoteRepo{store: map[string]domain.Quote{
+		"EUR/USD": {Pair: "EUR/USD", Price: 1.0, UpdatedAt: ts},
+	}}
+	ur := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"update-1": {ID: "update-1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusProcessing},
+	}}
+	qq := &fakeQuarantineRepo{}
+	svc := application.NewService(qr, ur, fakeRateProvider{}, redisstore.NoopIdempotency{},
+		application.WithQuoteGuard(application.QuoteGuard{MaxDeviationPct: 5}, qq))
+	h := NewRouter(NewServer(svc))
+
+	err := svc.CompleteQuoteUpdate(context.Background(), "update-1", func(context.Context) (domain.Quote, error) {
+		return domain.Quote{Pair: "EUR/USD", Price: 1.5, UpdatedAt: ts.Add(time.Minute)}, nil
+	}, "chan")
+	require.Error(t, err)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/quarantine?pair=EUR/USD", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list struct {
+		Items []struct {
+			ID     int64   `json:"id"`
+			Price  float64 `json:"price"`
+			Reason string  `json:"reason"`
+		} `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.Items, 1)
+	require.Equal(t, "deviation_exceeded", list.Items[0].Reason)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/quarantine/1/release", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, 1.5, qr.store["EUR/USD"].Price)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/quarantine/1/release", nil))
+	require.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/quarantine/abc/release", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/quarantine?limit=0", nil))
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
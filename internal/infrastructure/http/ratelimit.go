@@ -0,0 +1,154 @@
+package httpserver
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+
+	"go.uber.org/zap"
+)
+
+// RateLimits are the requests a client may make per window; 0 means unlimited.
+type RateLimits struct {
+	Read  int
+	Write int
+}
+
+// RateLimitPolicy sets the budgets of every client. Reads (GET, HEAD) and writes are
+// counted separately, so polling clients cannot starve their own updates.
+type RateLimitPolicy struct {
+	Window  time.Duration
+	Default RateLimits
+	// Clients overrides Default by API key name, API key id or client IP.
+	Clients map[string]RateLimits
+	// TrustProxy takes the client IP from X-Real-IP or the last X-Forwarded-For entry,
+	// as set by one reverse proxy in front of the API. Without it every client behind a
+	// proxy shares the proxy's address. Only enable it when clients cannot reach the API
+	// directly, or they can pick their own bucket.
+	TrustProxy bool
+}
+
+// SetRateLimiter limits each client, identified by its API key or else its IP, to the
+// budgets of p. Health checks and the API docs are not limited.
+func (s *Server) SetRateLimiter(l application.RateLimiter, p RateLimitPolicy) {
+	s.limiter, s.limits = l, p
+}
+
+// ParseRateLimitClients reads per-client budgets written as "client=read/write,...".
+func ParseRateLimitClients(spec string) (map[string]RateLimits, error) {
+	out := map[string]RateLimits{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		client, budgets, ok := strings.Cut(item, "=")
+		read, write, ok2 := strings.Cut(budgets, "/")
+		if !ok || !ok2 || strings.TrimSpace(client) == "" {
+			return nil, fmt.Errorf("rate limit %q: want client=read/write", item)
+		}
+		var l RateLimits
+		var err error
+		if l.Read, err = strconv.Atoi(strings.TrimSpace(read)); err != nil || l.Read < 0 {
+			return nil, fmt.Errorf("rate limit %q: invalid read budget", item)
+		}
+		if l.Write, err = strconv.Atoi(strings.TrimSpace(write)); err != nil || l.Write < 0 {
+			return nil, fmt.Errorf("rate limit %q: invalid write budget", item)
+		}
+		out[strings.TrimSpace(client)] = l
+	}
+	return out, nil
+}
+
+// clientIP returns the address of the client making r.
+func (p RateLimitPolicy) clientIP(r *http.Request) string {
+	if p.TrustProxy {
+		fwd := strings.TrimSpace(r.Header.Get("X-Real-IP"))
+		if fwd == "" {
+			// Earlier entries are whatever the client sent; the last one is the proxy's.
+			hops := strings.Split(r.Header.Get("X-Forwarded-For"), ",")
+			fwd = strings.TrimSpace(hops[len(hops)-1])
+		}
+		if net.ParseIP(fwd) != nil {
+			return fwd
+		}
+	}
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
+
+// clientLimits returns the bucket key and budgets of the client making r.
+func (p RateLimitPolicy) clientLimits(r *http.Request) (string, RateLimits) {
+	ip := p.clientIP(r)
+	if k, ok := application.APIKeyFromContext(r.Context()); ok {
+		for _, c := range []string{k.ID, k.Name, ip} {
+			if l, ok := p.Clients[c]; ok {
+				return "key:" + k.ID, l
+			}
+		}
+		return "key:" + k.ID, p.Default
+	}
+	if l, ok := p.Clients[ip]; ok {
+		return "ip:" + ip, l
+	}
+	return "ip:" + ip, p.Default
+}
+
+func rateLimit(s *Server) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, protected := routeScope(r); s.limiter == nil || !protected {
+				next.ServeHTTP(w, r)
+				return
+			}
+			client, limits := s.limits.clientLimits(r)
+			class, limit := "write", limits.Write
+			if r.Method == http.MethodGet || r.Method == http.MethodHead {
+				class, limit = "read", limits.Read
+			}
+			if limit <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			d, err := s.limiter.Allow(r.Context(), client+":"+class, limit, s.limits.Window)
+			if err != nil {
+				// Redis trouble should not take the API down with it.
+				loggerForRequest(r).Warn("rate_limit.unavailable", zap.Error(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+			setRateLimitHeaders(w, d, s.limits.Window)
+			if !d.Allowed {
+				loggerForRequest(r).Warn("rate_limit.exceeded",
+					zap.String("client", client), zap.String("class", class), zap.Int("limit", limit))
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(d.Reset)))
+				writeProblem(w, r, problemRateLimited, fmt.Sprintf("%s budget of %d requests per %s used up", class, limit, s.limits.Window))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// setRateLimitHeaders writes the RateLimit-* fields of the IETF draft on rate limit headers.
+func setRateLimitHeaders(w http.ResponseWriter, d domain.RateLimitDecision, window time.Duration) {
+	h := w.Header()
+	h.Set("RateLimit-Limit", strconv.Itoa(d.Limit))
+	h.Set("RateLimit-Remaining", strconv.Itoa(d.Remaining))
+	h.Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(d.Reset)))
+	h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", d.Limit, ceilSeconds(window)))
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,134 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := redisstore.NewRateLimiter(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	limiter.Now = func() time.Time { return now }
+
+	svc, _, _, _ := NewInMemoryService()
+	srv := NewServer(svc)
+	srv.SetAuthenticator(fakeAuthenticator{
+		"a": {ID: "k1", Name: "client-a", Scopes: []domain.Scope{domain.ScopeAdmin}},
+		"b": {ID: "k2", Name: "client-b", Scopes: []domain.Scope{domain.ScopeAdmin}},
+	})
+	srv.SetRateLimiter(limiter, RateLimitPolicy{
+		Window:  time.Minute,
+		Default: RateLimits{Read: 2, Write: 1},
+		Clients: map[string]RateLimits{"client-b": {Read: 0, Write: 3}},
+	})
+	h := NewRouter(srv)
+
+	do := func(method, key string, n int) *httptest.ResponseRecorder {
+		path := "/quotes/last?pair=EUR/USD"
+		var body *strings.Reader
+		if method == http.MethodPost {
+			path, body = "/quotes/updates", strings.NewReader(`{"pair":"EUR/USD"}`)
+		} else {
+			body = strings.NewReader("")
+		}
+		req := httptest.NewRequest(method, path, body)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Idempotency-Key", key+"-"+string(rune('0'+n)))
+		req.Header.Set("X-API-Key", key)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodGet, "a", 0)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Equal(t, "2", rec.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "1", rec.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "60", rec.Header().Get("RateLimit-Reset"))
+	require.Equal(t, "2;w=60", rec.Header().Get("RateLimit-Policy"))
+
+	now = now.Add(15 * time.Second)
+	require.Equal(t, http.StatusNotFound, do(http.MethodGet, "a", 1).Code)
+	rec = do(http.MethodGet, "a", 2)
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.Equal(t, "45", rec.Header().Get("Retry-After"))
+	require.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+
+	// Writes have their own budget.
+	require.Equal(t, http.StatusAccepted, do(http.MethodPost, "a", 3).Code)
+	require.Equal(t, http.StatusTooManyRequests, do(http.MethodPost, "a", 4).Code)
+
+	// client-b has its own limits: unlimited reads and three writes.
+	for i := 0; i < 3; i++ {
+		rec = do(http.MethodGet, "b", i)
+		require.Equal(t, http.StatusNotFound, rec.Code)
+		require.Empty(t, rec.Header().Get("RateLimit-Limit"))
+		require.Equal(t, http.StatusAccepted, do(http.MethodPost, "b", i).Code)
+	}
+	require.Equal(t, http.StatusTooManyRequests, do(http.MethodPost, "b", 3).Code)
+
+	// Health checks are never limited.
+	for i := 0; i < 3; i++ {
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	// Once the window has moved past the first read, a slot is free again.
+	now = now.Add(46 * time.Second)
+	require.Equal(t, http.StatusNotFound, do(http.MethodGet, "a", 5).Code)
+
+	// Redis being down lets requests through.
+	mr.Close()
+	require.Equal(t, http.StatusNotFound, do(http.MethodGet, "a", 6).Code)
+}
+
+func TestParseRateLimitClients(t *testing.T) {
+	got, err := ParseRateLimitClients(" dashboard=1200/120 , 10.0.0.5=0/0,")
+	require.NoError(t, err)
+	require.Equal(t, map[string]RateLimits{
+		"dashboard": {Read: 1200, Write: 120},
+		"10.0.0.5":  {Read: 0, Write: 0},
+	}, got)
+
+	for _, bad := range []string{"dashboard", "dashboard=10", "=1/2", "x=a/1", "x=1/-1"} {
+		_, err := ParseRateLimitClients(bad)
+		require.Error(t, err, bad)
+	}
+}
+
+func TestRateLimitPolicy_ClientIP(t *testing.T) {
+	req := func(headers ...string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "/quotes/last", nil)
+		r.RemoteAddr = "10.0.0.1:4242"
+		for i := 0; i < len(headers); i += 2 {
+			r.Header.Set(headers[i], headers[i+1])
+		}
+		return r
+	}
+	direct := RateLimitPolicy{}
+	require.Equal(t, "10.0.0.1", direct.clientIP(req("X-Forwarded-For", "203.0.113.7")), "headers are ignored unless trusted")
+
+	proxied := RateLimitPolicy{TrustProxy: true}
+	require.Equal(t, "10.0.0.1", proxied.clientIP(req()))
+	require.Equal(t, "203.0.113.7", proxied.clientIP(req("X-Real-IP", "203.0.113.7")))
+	// Only the entry appended by the proxy counts; the client may have sent the rest.
+	require.Equal(t, "203.0.113.7", proxied.clientIP(req("X-Forwarded-For", "198.51.100.1, 203.0.113.7")))
+	require.Equal(t, "10.0.0.1", proxied.clientIP(req("X-Forwarded-For", "not-an-ip")))
+
+	key, _ := proxied.clientLimits(req("X-Real-IP", "203.0.113.7"))
+	require.Equal(t, "ip:203.0.113.7", key)
+}
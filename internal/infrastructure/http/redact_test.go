@@ -0,0 +1,71 @@
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/httpx"
+	"fxrates-service/internal/infrastructure/provider"
+	"fxrates-service/internal/infrastructure/redact"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+
+	"github.com/stretchr/testify/require"
+)
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection reset by peer")
+}
+
+func TestGetQuoteUpdate_NeverExposesProviderKey(t *testing.T) {
+	const key = "k3y-that-must-not-leak"
+	prov := &provider.ExchangeRatesAPIProvider{
+		BaseURL: "http://upstream.test",
+		APIKey:  key,
+		Client:  &httpx.Client{HTTP: &http.Client{Transport: failingTransport{}}},
+		Retry:   &httpx.RetryPolicy{Initial: time.Millisecond, Max: time.Millisecond, Total: 5 * time.Millisecond},
+	}
+	qr, ur, _ := NewInMemoryRepos()
+	svc := application.NewService(qr, ur, prov, redisstore.NoopIdempotency{}, application.WithRedactor(redact.String))
+	h := NewRouter(NewServer(svc))
+
+	req := httptest.NewRequest(http.MethodPost, "/quotes/updates", bytes.NewBufferString(`{"pair":"EUR/USD"}`))
+	req.Header.Set("X-Idempotency-Key", "k1")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	require.Error(t, svc.ProcessQueueBatch(context.Background(), 10))
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/quotes/updates/update-1", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `"status":"failed"`)
+	require.Contains(t, rec.Body.String(), "connection reset")
+	require.NotContains(t, rec.Body.String(), key)
+}
+
+func TestGetQuoteUpdate_RedactsStoredErrors(t *testing.T) {
+	redact.Register("legacy-secret-value")
+	msg := "Get https://upstream.test/latest?access_key=legacy-secret-value: EOF"
+	qr, _, rp := NewInMemoryRepos()
+	ur := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"update-1": {ID: "update-1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusFailed, Error: &msg},
+	}}
+	svc := application.NewService(qr, ur, rp, redisstore.NoopIdempotency{}, application.WithRedactor(redact.String))
+	h := NewRouter(NewServer(svc))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/quotes/updates/update-1", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NotContains(t, rec.Body.String(), "legacy-secret-value")
+	require.Contains(t, rec.Body.String(), "access_key=REDACTED")
+}
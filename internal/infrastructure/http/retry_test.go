@@ -0,0 +1,96 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryQuoteUpdate(t *testing.T) {
+	qr := &fakeQuoteRepo{store: map[string]domain.Quote{}}
+	ur := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}
+	msg := "timeout"
+	ur.jobs["f"] = domain.QuoteUpdate{ID: "f", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusFailed, Error: &msg}
+	ur.jobs["d"] = domain.QuoteUpdate{ID: "d", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusDone}
+	started := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	attempts := &fakeAttemptRepo{attempts: map[string][]domain.QuoteUpdateAttempt{
+		"f": {{Number: 1, Worker: "chan", StartedAt: started, FinishedAt: &started, Error: &msg}},
+	}}
+	svc := application.NewService(qr, ur, fakeRateProvider{}, redisstore.NoopIdempotency{},
+		application.WithAttempts(attempts))
+	srv := NewServer(svc)
+	var dispatched []string
+	srv.SetDispatcher(func(_ context.Context, id, _, _ string) error {
+		dispatched = append(dispatched, id)
+		return nil
+	})
+	h := NewRouter(srv)
+
+	retry := func(id string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/quotes/updates/"+id+"/retry", nil))
+		return rec
+	}
+
+	rec := retry("f")
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var got struct {
+		Status   string  `json:"status"`
+		Error    *string `json:"error"`
+		Attempts []struct {
+			Number int     `json:"number"`
+			Worker string  `json:"worker"`
+			Error  *string `json:"error"`
+		} `json:"attempts"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, "queued", got.Status)
+	require.Nil(t, got.Error)
+	require.Len(t, got.Attempts, 1)
+	require.Equal(t, "chan", got.Attempts[0].Worker)
+	require.Equal(t, "timeout", *got.Attempts[0].Error)
+	require.Equal(t, []string{"f"}, dispatched)
+
+	require.Equal(t, http.StatusConflict, retry("f").Code)
+	require.Equal(t, http.StatusConflict, retry("d").Code)
+	require.Equal(t, http.StatusNotFound, retry("missing").Code)
+}
+
+func TestRetryQuoteUpdate_DispatchUnavailable(t *testing.T) {
+	ur := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}
+	msg := "timeout"
+	ur.jobs["f"] = domain.QuoteUpdate{ID: "f", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusFailed, Error: &msg}
+	srv := NewServer(application.NewService(&fakeQuoteRepo{store: map[string]domain.Quote{}}, ur, fakeRateProvider{}, redisstore.NoopIdempotency{}))
+	fail := true
+	srv.SetDispatcher(func(context.Context, string, string, string) error {
+		if fail {
+			return errors.New("queue full")
+		}
+		return nil
+	})
+	h := NewRouter(srv)
+	retry := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/quotes/updates/f/retry", nil))
+		return rec
+	}
+
+	rec := retry()
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	requireProblem(t, rec, problemDispatchUnavailable)
+	require.Equal(t, domain.QuoteUpdateStatusFailed, ur.jobs["f"].Status, "an undispatched retry can be retried again")
+
+	fail = false
+	require.Equal(t, http.StatusAccepted, retry().Code)
+	require.Equal(t, domain.QuoteUpdateStatusQueued, ur.jobs["f"].Status)
+}
@@ -1,11 +1,14 @@
 package httpserver
 
 import (
+	"bufio"
 	"context"
+	"net"
 	"net/http"
 	"os"
 	"time"
 
+	"fxrates-service/internal/application"
 	"fxrates-service/internal/infrastructure/http/openapi"
 	"fxrates-service/internal/infrastructure/logx"
 
@@ -26,6 +29,8 @@ func NewRouter(s *Server) http.Handler {
 	r.Use(traceID())
 	r.Use(recoverer())
 	r.Use(accessLog())
+	r.Use(authenticate(s))
+	r.Use(rateLimit(s))
 
 	r.Get("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -35,7 +40,7 @@ func NewRouter(s *Server) http.Handler {
 	r.Get("/readyz", func(w http.ResponseWriter, r *http.Request) {
 		if s.ping != nil {
 			if err := s.ping(r.Context()); err != nil {
-				writeError(w, http.StatusServiceUnavailable, "db not ready")
+				writeProblem(w, r, problemNotReady, "database is not reachable")
 				return
 			}
 		}
@@ -43,11 +48,20 @@ func NewRouter(s *Server) http.Handler {
 		w.Write([]byte("READY"))
 	})
 
+	r.Get("/ws", s.ServeWS)
+
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, r, problemNotFound, "no route for "+r.URL.Path)
+	})
+	r.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, r, problemMethodNotAllowed, r.Method+" is not supported on "+r.URL.Path)
+	})
+
 	// Use custom error handler to ensure JSON error envelope on binding/validation errors
 	openapi.HandlerWithOptions(s, openapi.ChiServerOptions{
 		BaseRouter: r,
 		ErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			writeError(w, http.StatusBadRequest, "bad request")
+			writeProblem(w, r, problemValidationFailed, "", bindingProblem(err)...)
 		},
 	})
 
@@ -106,7 +120,7 @@ func recoverer() func(http.Handler) http.Handler {
 				if rec := recover(); rec != nil {
 					rid, _ := r.Context().Value(requestIDKey).(string)
 					logx.L().Error("panic recovered", zap.Any("error", rec), zap.String("request_id", rid))
-					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					writeProblem(w, r, problemInternal, "")
 				}
 			}()
 			next.ServeHTTP(w, r)
@@ -118,6 +132,8 @@ type statusRecorder struct {
 	http.ResponseWriter
 	status int
 	bytes  int
+	// apiKeyID is set by the auth middleware for the access log.
+	apiKeyID string
 }
 
 func (sr *statusRecorder) WriteHeader(code int) {
@@ -134,6 +150,19 @@ func (sr *statusRecorder) Write(b []byte) (int, error) {
 	return n, err
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush streams.
+func (sr *statusRecorder) Unwrap() http.ResponseWriter { return sr.ResponseWriter }
+
+// Hijack hands the connection to protocol upgrades such as WebSocket, which write
+// the 101 response themselves.
+func (sr *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(sr.ResponseWriter).Hijack()
+	if err == nil {
+		sr.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
 func accessLog() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -142,14 +171,18 @@ func accessLog() func(http.Handler) http.Handler {
 			next.ServeHTTP(sr, r)
 			rid, _ := r.Context().Value(requestIDKey).(string)
 			tid, _ := r.Context().Value(traceIDKey).(string)
-			logx.L().Info("http_request",
+			fields := []zap.Field{
 				zap.String("method", r.Method),
 				zap.String("path", r.URL.Path),
 				zap.Int("status", sr.status),
 				zap.String("request_id", rid),
 				zap.String("trace_id", tid),
 				zap.Duration("duration", time.Since(start)),
-			)
+			}
+			if sr.apiKeyID != "" {
+				fields = append(fields, zap.String("api_key_id", sr.apiKeyID))
+			}
+			logx.L().Info("http_request", fields...)
 		})
 	}
 }
@@ -163,6 +196,8 @@ func traceID() func(http.Handler) http.Handler {
 			}
 			w.Header().Set("X-Trace-Id", tid)
 			ctx := context.WithValue(r.Context(), traceIDKey, tid)
+			// Synchronous provider calls made while serving the request forward the same ID.
+			ctx = application.ContextWithTraceID(ctx, tid)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
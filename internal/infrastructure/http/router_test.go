@@ -62,7 +62,7 @@ func TestGetQuoteUpdate_NotFound(t *testing.T) {
 	rec := httptest.NewRecorder()
 	h.ServeHTTP(rec, req)
 	require.Equal(t, http.StatusNotFound, rec.Code)
-	require.JSONEq(t, `{"code":404,"message":"not found"}`, rec.Body.String())
+	requireProblem(t, rec, problemNotFound)
 }
 
 func TestGetLastQuote_EmptyStore(t *testing.T) {
@@ -71,7 +71,7 @@ func TestGetLastQuote_EmptyStore(t *testing.T) {
 	rec := httptest.NewRecorder()
 	h.ServeHTTP(rec, req)
 	require.Equal(t, http.StatusNotFound, rec.Code)
-	require.JSONEq(t, `{"code":404,"message":"not found"}`, rec.Body.String())
+	requireProblem(t, rec, problemNotFound)
 }
 
 func TestRequestQuoteUpdate_InvalidPair(t *testing.T) {
@@ -84,7 +84,7 @@ func TestRequestQuoteUpdate_InvalidPair(t *testing.T) {
 	rec := httptest.NewRecorder()
 	h.ServeHTTP(rec, req)
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.JSONEq(t, `{"code":400,"message":"invalid pair"}`, rec.Body.String())
+	requireProblem(t, rec, problemInvalidPair, "pair")
 }
 
 func TestRequestQuoteUpdate_UnsupportedPair_HTTP(t *testing.T) {
@@ -97,7 +97,7 @@ func TestRequestQuoteUpdate_UnsupportedPair_HTTP(t *testing.T) {
 	rec := httptest.NewRecorder()
 	h.ServeHTTP(rec, req)
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.JSONEq(t, `{"code":400,"message":"invalid pair"}`, rec.Body.String())
+	requireProblem(t, rec, problemUnsupportedCurrency, "pair")
 }
 
 func TestGetLastQuote_UnsupportedPair_HTTP(t *testing.T) {
@@ -106,7 +106,7 @@ func TestGetLastQuote_UnsupportedPair_HTTP(t *testing.T) {
 	rec := httptest.NewRecorder()
 	h.ServeHTTP(rec, req)
 	require.Equal(t, http.StatusBadRequest, rec.Code)
-	require.JSONEq(t, `{"code":400,"message":"invalid pair"}`, rec.Body.String())
+	requireProblem(t, rec, problemUnsupportedCurrency, "pair")
 }
 func TestGetQuoteUpdate_WithPrice(t *testing.T) {
 	// Prepare in-memory service and pre-populate a completed update with price and timestamp
@@ -147,44 +147,68 @@ func TestGetQuoteUpdate_WithPrice(t *testing.T) {
 	require.Equal(t, ts, resp.UpdatedAt)
 }
 
-type memIdem struct{ seen map[string]bool }
+type memIdem struct {
+	recs map[string]domain.IdempotencyRecord
+}
 
-func (m *memIdem) TryReserve(_ context.Context, k string) (bool, error) {
-	if m.seen == nil {
-		m.seen = map[string]bool{}
+func (m *memIdem) Reserve(_ context.Context, k, fp string) (domain.IdempotencyRecord, bool, error) {
+	if m.recs == nil {
+		m.recs = map[string]domain.IdempotencyRecord{}
 	}
-	if m.seen[k] {
-		return false, nil
+	if rec, ok := m.recs[k]; ok {
+		return rec, false, nil
 	}
-	m.seen[k] = true
-	return true, nil
+	m.recs[k] = domain.IdempotencyRecord{Fingerprint: fp}
+	return domain.IdempotencyRecord{}, true, nil
+}
+
+func (m *memIdem) Complete(_ context.Context, k string, rec domain.IdempotencyRecord) error {
+	m.recs[k] = rec
+	return nil
+}
+
+func (m *memIdem) Release(_ context.Context, k string) error {
+	delete(m.recs, k)
+	return nil
 }
 
-func TestRequestQuoteUpdate_IdempotencyConflict_HTTP(t *testing.T) {
+func TestRequestQuoteUpdate_IdempotentReplay_HTTP(t *testing.T) {
 	qr, ur, rp := NewInMemoryRepos()
 	idem := &memIdem{}
 	svc := application.NewService(qr, ur, rp, idem)
 	srv := NewServer(svc)
+	dispatched := 0
+	srv.SetDispatcher(func(context.Context, string, string, string) error {
+		dispatched++
+		return nil
+	})
 	h := NewRouter(srv)
 
-	body := map[string]string{"pair": "EUR/USD"}
-	b, _ := json.Marshal(body)
-	// First call should 202
-	req1 := httptest.NewRequest(http.MethodPost, "/quotes/updates", bytes.NewReader(b))
-	req1.Header.Set("Content-Type", "application/json")
-	req1.Header.Set("X-Idempotency-Key", "k-dup")
-	rec1 := httptest.NewRecorder()
-	h.ServeHTTP(rec1, req1)
+	post := func(pair string) *httptest.ResponseRecorder {
+		b, _ := json.Marshal(map[string]string{"pair": pair})
+		req := httptest.NewRequest(http.MethodPost, "/quotes/updates", bytes.NewReader(b))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Idempotency-Key", "k-dup")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec1 := post("EUR/USD")
 	require.Equal(t, http.StatusAccepted, rec1.Code)
+	require.Empty(t, rec1.Header().Get("Idempotent-Replayed"))
+
+	// A retry with the same body gets the original response and is not dispatched again.
+	rec2 := post("EUR/USD")
+	require.Equal(t, http.StatusAccepted, rec2.Code)
+	require.JSONEq(t, rec1.Body.String(), rec2.Body.String())
+	require.Equal(t, "true", rec2.Header().Get("Idempotent-Replayed"))
+	require.Equal(t, 1, dispatched)
 
-	// Second call should 409 conflict with JSON Error envelope
-	req2 := httptest.NewRequest(http.MethodPost, "/quotes/updates", bytes.NewReader(b))
-	req2.Header.Set("Content-Type", "application/json")
-	req2.Header.Set("X-Idempotency-Key", "k-dup")
-	rec2 := httptest.NewRecorder()
-	h.ServeHTTP(rec2, req2)
-	require.Equal(t, http.StatusConflict, rec2.Code)
-	require.JSONEq(t, `{"code":409,"message":"conflict"}`, rec2.Body.String())
+	// Reusing the key for another pair is rejected with a problem document.
+	rec3 := post("USD/MXN")
+	require.Equal(t, http.StatusUnprocessableEntity, rec3.Code)
+	requireProblem(t, rec3, problemIdempotencyMismatch)
 }
 
 func Test_GetQuoteUpdate_PendingVsDone(t *testing.T) {
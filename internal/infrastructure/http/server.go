@@ -10,6 +10,8 @@ import (
 	"fxrates-service/internal/infrastructure/http/openapi"
 	"fxrates-service/internal/infrastructure/logx"
 	"net/http"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -18,38 +20,61 @@ type Server struct {
 	svc      *application.FXRatesService
 	ping     func(context.Context) error
 	dispatch func(ctx context.Context, id, pair, traceID string) error
+	auth     application.Authenticator
+	limiter  application.RateLimiter
+	limits   RateLimitPolicy
+	maxAge   map[string]time.Duration
+	// wsOrigins are the cross-site origins allowed to open /ws.
+	wsOrigins []string
+
+	heartbeat time.Duration
+	// closing is closed on shutdown so long-lived streams end instead of holding it up.
+	closing   chan struct{}
+	closeOnce sync.Once
 }
 
-func NewServer(svc *application.FXRatesService) *Server { return &Server{svc: svc} }
+func NewServer(svc *application.FXRatesService) *Server {
+	return &Server{svc: svc, heartbeat: defaultStreamHeartbeat, closing: make(chan struct{})}
+}
 
 func (s *Server) SetReadyCheck(fn func(context.Context) error) { s.ping = fn }
 
+// SetStreamHeartbeat sets how often idle event streams send a comment line.
+func (s *Server) SetStreamHeartbeat(d time.Duration) {
+	if d > 0 {
+		s.heartbeat = d
+	}
+}
+
 func (s *Server) SetDispatcher(fn func(context.Context, string, string, string) error) {
 	s.dispatch = fn
 }
 
+// replayedHeader marks a response answered from the idempotency store.
+const replayedHeader = "Idempotent-Replayed"
+
 func (s *Server) RequestQuoteUpdate(w http.ResponseWriter, r *http.Request, params openapi.RequestQuoteUpdateParams) {
 	log := loggerForRequest(r)
 	var body openapi.QuoteUpdateRequest
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		log.Warn("request_quote_update.decode_failed", zap.Error(err))
-		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		writeProblem(w, r, problemMalformedBody, err.Error())
 		return
 	}
 	if body.Pair == "" {
 		log.Warn("request_quote_update.missing_pair")
-		writeError(w, http.StatusBadRequest, "pair is required")
+		writeProblem(w, r, problemValidationFailed, "", fieldError("pair", "is required"))
 		return
 	}
 	if !domain.ValidatePair(body.Pair) {
 		log.Warn("request_quote_update.invalid_pair_format", zap.String("pair", body.Pair))
-		writeError(w, http.StatusBadRequest, "invalid pair")
+		writePairProblem(w, r, "pair", body.Pair)
 		return
 	}
 	idem := r.Header.Get("X-Idempotency-Key")
 	if idem == "" {
 		log.Warn("request_quote_update.missing_idem")
-		writeError(w, http.StatusBadRequest, "X-Idempotency-Key is required")
+		writeProblem(w, r, problemValidationFailed, "", fieldError("X-Idempotency-Key", "is required"))
 		return
 	}
 	log = log.With(
@@ -57,77 +82,183 @@ func (s *Server) RequestQuoteUpdate(w http.ResponseWriter, r *http.Request, para
 		zap.String("idempotency_key", idem),
 	)
 	log.Info("request_quote_update.call_service")
-	id, err := s.svc.RequestQuoteUpdate(r.Context(), body.Pair, &idem)
+	// Dispatch before answering so that a failure can still be reported. An accepted
+	// update's status is stored with the idempotency key and replayed to retries; a failed
+	// dispatch withdraws the update and frees the key, so the retry dispatches again.
+	id, status, replayed, err := s.svc.RequestQuoteUpdate(r.Context(), body.Pair, &idem, func(id string) (int, error) {
+		log.Info("request_quote_update.queued", zap.String("update_id", id))
+		if s.dispatch == nil {
+			return http.StatusAccepted, nil
+		}
+		log.Info("request_quote_update.dispatch")
+		if err := s.dispatch(r.Context(), id, body.Pair, getTraceIDFromContext(r.Context())); err != nil {
+			log.Warn("request_quote_update.dispatch_failed", zap.Error(err))
+			return http.StatusServiceUnavailable, err
+		}
+		return http.StatusAccepted, nil
+	})
 	if err != nil {
 		switch {
 		case errors.Is(err, application.ErrBadRequest):
-			writeError(w, http.StatusBadRequest, "bad request")
+			writeProblem(w, r, problemValidationFailed, "")
 			return
 		case errors.Is(err, domain.ErrUnsupportedPair):
-			writeError(w, http.StatusBadRequest, "invalid pair")
+			writeProblem(w, r, problemUnsupportedCurrency, "", fieldError("pair", "is not quoted"))
 			return
 		case errors.Is(err, application.ErrConflict):
-			writeError(w, http.StatusConflict, "conflict")
+			writeProblem(w, r, problemIdempotencyConflict, "a request with this X-Idempotency-Key is still being processed")
+			return
+		case errors.Is(err, application.ErrIdempotencyMismatch):
+			log.Warn("request_quote_update.idempotency_mismatch")
+			writeProblem(w, r, problemIdempotencyMismatch, "X-Idempotency-Key was used for another pair")
 			return
 		default:
 			logRequestError(r, "request quote update failed", err)
-			writeError(w, http.StatusInternalServerError, "internal error")
+			writeProblem(w, r, problemInternal, "")
 		}
 		return
 	}
-	log.Info("request_quote_update.queued", zap.String("update_id", id))
-	resp := openapi.QuoteUpdateResponse{UpdateId: id}
-	writeJSON(w, http.StatusAccepted, resp)
-
-	traceID := getTraceIDFromContext(r.Context())
+	if replayed {
+		// The original request already dispatched the job.
+		log.Info("request_quote_update.replayed", zap.String("update_id", id), zap.Int("status", status))
+		w.Header().Set(replayedHeader, "true")
+	}
+	if status == http.StatusServiceUnavailable {
+		writeProblem(w, r, problemDispatchUnavailable, "no worker took update "+id+", so it was canceled; retry with the same X-Idempotency-Key")
+		return
+	}
+	writeJSON(w, acceptedStatus(status), openapi.QuoteUpdateResponse{UpdateId: id})
+}
 
-	if s.dispatch != nil {
-		log.Info("request_quote_update.dispatch")
-		if err := s.dispatch(r.Context(), id, body.Pair, traceID); err != nil {
-			log.Warn("request_quote_update.dispatch_failed", zap.Error(err))
-			writeError(w, http.StatusServiceUnavailable, "background dispatch failed")
-			return
-		}
+// acceptedStatus maps the status stored with an idempotency key to the one to answer
+// with; zero means the record predates stored statuses, when every answer was 202.
+func acceptedStatus(status int) int {
+	if status == 0 {
+		return http.StatusAccepted
 	}
+	return status
 }
 
-func (s *Server) GetQuoteUpdate(w http.ResponseWriter, r *http.Request, id string) {
+func (s *Server) GetQuoteUpdate(w http.ResponseWriter, r *http.Request, id string, params openapi.GetQuoteUpdateParams) {
 	log := loggerForRequest(r).With(zap.String("update_id", id))
-	log.Info("get_quote_update.call_service")
-	upd, err := s.svc.GetQuoteUpdate(r.Context(), id)
+	var wait time.Duration
+	if params.Wait != nil {
+		d, err := time.ParseDuration(*params.Wait)
+		if err != nil || d < 0 {
+			writeProblem(w, r, problemValidationFailed, "", fieldError("wait", "must be a non-negative duration such as 10s"))
+			return
+		}
+		wait = d
+	}
+	log.Info("get_quote_update.call_service", zap.Duration("wait", wait))
+	upd, err := s.svc.WaitQuoteUpdate(r.Context(), id, wait)
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			log.Info("get_quote_update.not_found")
-			writeError(w, http.StatusNotFound, "not found")
+			writeProblem(w, r, problemNotFound, "no update with id "+id)
+			return
+		}
+		if errors.Is(err, context.Canceled) {
+			log.Info("get_quote_update.client_gone")
 			return
 		}
 		logRequestError(r, "get quote update failed", err)
-		writeError(w, http.StatusInternalServerError, "internal error")
+		writeProblem(w, r, problemInternal, "")
 		return
 	}
 	log.Info("get_quote_update.success", zap.String("status", string(upd.Status)))
+	// No Last-Modified: updated_at stays put while a job is claimed or retried and becomes
+	// the provider's quote time on completion, which can lie before the request.
+	s.writeCacheableJSON(w, r, cacheRouteQuoteUpdate, toQuoteUpdateDetails(upd), time.Time{})
+}
+
+func (s *Server) CancelQuoteUpdate(w http.ResponseWriter, r *http.Request, id string) {
+	log := loggerForRequest(r).With(zap.String("update_id", id))
+	upd, err := s.svc.CancelQuoteUpdate(r.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeProblem(w, r, problemNotFound, "no update with id "+id)
+		case errors.Is(err, application.ErrConflict):
+			log.Info("cancel_quote_update.not_queued")
+			writeProblem(w, r, problemStateConflict, "update is no longer queued")
+		default:
+			logRequestError(r, "cancel quote update failed", err)
+			writeProblem(w, r, problemInternal, "")
+		}
+		return
+	}
+	log.Info("cancel_quote_update.success")
+	writeJSON(w, http.StatusOK, toQuoteUpdateDetails(upd))
+}
+
+func (s *Server) RetryQuoteUpdate(w http.ResponseWriter, r *http.Request, id string) {
+	log := loggerForRequest(r).With(zap.String("update_id", id))
+	var dispatch func(domain.QuoteUpdate) error
+	if s.dispatch != nil {
+		dispatch = func(upd domain.QuoteUpdate) error {
+			log.Info("retry_quote_update.dispatch")
+			return s.dispatch(r.Context(), upd.ID, string(upd.Pair), getTraceIDFromContext(r.Context()))
+		}
+	}
+	upd, err := s.svc.RetryQuoteUpdate(r.Context(), id, dispatch)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeProblem(w, r, problemNotFound, "no update with id "+id)
+		case errors.Is(err, application.ErrConflict):
+			log.Info("retry_quote_update.not_failed")
+			writeProblem(w, r, problemStateConflict, "only failed updates can be retried")
+		case errors.Is(err, application.ErrDispatchUnavailable):
+			log.Warn("retry_quote_update.dispatch_failed", zap.Error(err))
+			writeProblem(w, r, problemDispatchUnavailable, "no worker took update "+id+", so it stays failed; retry it later")
+		default:
+			logRequestError(r, "retry quote update failed", err)
+			writeProblem(w, r, problemInternal, "")
+		}
+		return
+	}
+	log.Info("retry_quote_update.queued")
+	writeJSON(w, http.StatusAccepted, toQuoteUpdateDetails(upd))
+}
+
+func toQuoteUpdateDetails(upd domain.QuoteUpdate) openapi.QuoteUpdateDetails {
 	// Map price (*float64) to OpenAPI price (*float32)
 	var price *float32
 	if upd.Price != nil {
 		p := float32(*upd.Price)
 		price = &p
 	}
-	resp := openapi.QuoteUpdateDetails{
+	var attempts *[]openapi.QuoteUpdateAttempt
+	if upd.Attempts != nil {
+		as := make([]openapi.QuoteUpdateAttempt, 0, len(upd.Attempts))
+		for _, a := range upd.Attempts {
+			as = append(as, openapi.QuoteUpdateAttempt{
+				Number:     a.Number,
+				Worker:     a.Worker,
+				StartedAt:  a.StartedAt,
+				FinishedAt: a.FinishedAt,
+				Error:      a.Error,
+			})
+		}
+		attempts = &as
+	}
+	return openapi.QuoteUpdateDetails{
 		UpdateId:  upd.ID,
 		Pair:      string(upd.Pair),
 		Error:     upd.Error,
 		Status:    mapStatus(upd.Status),
 		Price:     price,
 		UpdatedAt: upd.UpdatedAt,
+		Attempts:  attempts,
 	}
-	writeJSON(w, http.StatusOK, resp)
 }
 
 func (s *Server) GetLastQuote(w http.ResponseWriter, r *http.Request, params openapi.GetLastQuoteParams) {
 	log := loggerForRequest(r).With(zap.String("pair", params.Pair))
 	if !domain.ValidatePair(params.Pair) {
 		log.Warn("get_last_quote.invalid_pair_format")
-		writeError(w, http.StatusBadRequest, "invalid pair")
+		writePairProblem(w, r, "pair", params.Pair)
 		return
 	}
 	log.Info("get_last_quote.call_service")
@@ -135,25 +266,28 @@ func (s *Server) GetLastQuote(w http.ResponseWriter, r *http.Request, params ope
 	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			log.Info("get_last_quote.not_found")
-			writeError(w, http.StatusNotFound, "not found")
+			writeProblem(w, r, problemNotFound, "no quote stored for "+params.Pair)
 			return
 		}
 		logRequestError(r, "get last quote failed", err)
-		writeError(w, http.StatusInternalServerError, "internal error")
+		writeProblem(w, r, problemInternal, "")
 		return
 	}
 	log.Info("get_last_quote.success", zap.Float64("price", q.Price))
+	s.writeCacheableJSON(w, r, cacheRouteLastQuote, toLastQuote(q), q.UpdatedAt)
+}
+
+func toLastQuote(q domain.Quote) openapi.LastQuote {
 	var price *float32
 	if q.Price != 0 {
 		p := float32(q.Price)
 		price = &p
 	}
-	resp := openapi.LastQuote{
+	return openapi.LastQuote{
 		Pair:      string(q.Pair),
 		Price:     price,
 		UpdatedAt: q.UpdatedAt,
 	}
-	writeJSON(w, http.StatusOK, resp)
 }
 
 // Run starts the HTTP server and blocks until the context is canceled or the server stops.
@@ -163,6 +297,7 @@ func (s *Server) Run(ctx context.Context) error {
 		Addr:    addr,
 		Handler: NewRouter(s),
 	}
+	server.RegisterOnShutdown(func() { s.closeOnce.Do(func() { close(s.closing) }) })
 	logx.L().Info("server started", zap.String("addr", addr))
 	errCh := make(chan error, 1)
 	go func() {
@@ -193,17 +328,6 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 	_ = json.NewEncoder(w).Encode(v)
 }
 
-type apiError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-}
-
-func writeError(w http.ResponseWriter, code int, msg string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-	_ = json.NewEncoder(w).Encode(apiError{Code: code, Message: msg})
-}
-
 func logRequestError(r *http.Request, msg string, err error) {
 	if err == nil {
 		return
@@ -216,23 +340,29 @@ func logRequestError(r *http.Request, msg string, err error) {
 func loggerForRequest(r *http.Request) *zap.Logger {
 	rid, _ := r.Context().Value(requestIDKey).(string)
 	tid := r.Header.Get("X-Trace-Id")
-	return logx.L().With(
+	log := logx.L().With(
 		zap.String("request_id", rid),
 		zap.String("trace_id", tid),
 		zap.String("method", r.Method),
 		zap.String("path", r.URL.Path),
 	)
+	if k, ok := application.APIKeyFromContext(r.Context()); ok {
+		log = log.With(zap.String("api_key_id", k.ID), zap.String("api_key_name", k.Name))
+	}
+	return log
 }
 
 func mapStatus(s domain.QuoteUpdateStatus) openapi.QuoteUpdateDetailsStatus {
 	switch s {
 	case domain.QuoteUpdateStatusQueued:
-		return openapi.Queued
+		return openapi.QuoteUpdateDetailsStatusQueued
 	case domain.QuoteUpdateStatusProcessing:
-		return openapi.Processing
+		return openapi.QuoteUpdateDetailsStatusProcessing
 	case domain.QuoteUpdateStatusDone:
-		return openapi.Done
+		return openapi.QuoteUpdateDetailsStatusDone
+	case domain.QuoteUpdateStatusCanceled:
+		return openapi.QuoteUpdateDetailsStatusCanceled
 	default:
-		return openapi.Failed
+		return openapi.QuoteUpdateDetailsStatusFailed
 	}
 }
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fxrates-service/internal/domain"
 	openapi "fxrates-service/internal/infrastructure/http/openapi"
-	"github.com/stretchr/testify/require"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -16,10 +15,11 @@ func Test_mapStatus(t *testing.T) {
 		in  domain.QuoteUpdateStatus
 		out openapi.QuoteUpdateDetailsStatus
 	}{
-		{domain.QuoteUpdateStatusQueued, openapi.Queued},
-		{domain.QuoteUpdateStatusProcessing, openapi.Processing},
-		{domain.QuoteUpdateStatusDone, openapi.Done},
-		{domain.QuoteUpdateStatusFailed, openapi.Failed},
+		{domain.QuoteUpdateStatusQueued, openapi.QuoteUpdateDetailsStatusQueued},
+		{domain.QuoteUpdateStatusProcessing, openapi.QuoteUpdateDetailsStatusProcessing},
+		{domain.QuoteUpdateStatusDone, openapi.QuoteUpdateDetailsStatusDone},
+		{domain.QuoteUpdateStatusFailed, openapi.QuoteUpdateDetailsStatusFailed},
+		{domain.QuoteUpdateStatusCanceled, openapi.QuoteUpdateDetailsStatusCanceled},
 	}
 	for _, c := range cases {
 		got := mapStatus(c.in)
@@ -41,11 +41,5 @@ func Test_readyz_FailingCheck(t *testing.T) {
 	if rec.Code != http.StatusServiceUnavailable {
 		t.Fatalf("expected 503, got %d", rec.Code)
 	}
-	want := `{"code":503,"message":"db not ready"}`
-	// Compare JSON structurally to ignore whitespace/newlines
-	if rec.Body.Len() == 0 {
-		t.Fatalf("empty body")
-	}
-	// Use JSONEq to avoid formatting differences
-	require.JSONEq(t, want, rec.Body.String())
+	requireProblem(t, rec, problemNotReady)
 }
@@ -0,0 +1,92 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/http/openapi"
+
+	"go.uber.org/zap"
+)
+
+// defaultStreamHeartbeat keeps idle streams alive through proxies that drop silent connections.
+const defaultStreamHeartbeat = 15 * time.Second
+
+// streamRetryMillis is the reconnect delay suggested to EventSource clients.
+const streamRetryMillis = 3000
+
+func (s *Server) StreamQuotes(w http.ResponseWriter, r *http.Request, params openapi.StreamQuotesParams) {
+	log := loggerForRequest(r)
+	pairs := splitPairs(params.Pairs)
+	if len(pairs) > maxLatestPairs {
+		writeProblem(w, r, problemValidationFailed, "", fieldError("pairs", fmt.Sprintf("at most %d pairs", maxLatestPairs)))
+		return
+	}
+	for _, p := range pairs {
+		if !domain.ValidatePair(p) {
+			writePairProblem(w, r, "pairs", p)
+			return
+		}
+	}
+	events, err := s.svc.WatchQuotes(r.Context(), pairs, params.LastEventID)
+	if err != nil {
+		if errors.Is(err, application.ErrQuoteFeedUnavailable) {
+			writeProblem(w, r, problemStreamingUnavailable, "")
+			return
+		}
+		logRequestError(r, "stream quotes failed", err)
+		writeProblem(w, r, problemInternal, "")
+		return
+	}
+	rc := http.NewResponseController(w)
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetryMillis)
+	if err := rc.Flush(); err != nil {
+		log.Warn("stream_quotes.flush_unsupported", zap.Error(err))
+		return
+	}
+	log.Info("stream_quotes.open", zap.Strings("pairs", pairs))
+
+	heartbeat := time.NewTicker(s.heartbeat)
+	defer heartbeat.Stop()
+	sent := 0
+	defer func() { log.Info("stream_quotes.closed", zap.Int("events", sent)) }()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.closing:
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case ev, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(toLastQuote(domain.Quote{Pair: ev.Pair, Price: ev.Price, UpdatedAt: ev.QuotedAt}))
+			if err != nil {
+				log.Error("stream_quotes.encode_failed", zap.Error(err))
+				return
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: quote\ndata: %s\n\n", ev.ID, data); err != nil {
+				return
+			}
+			sent++
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package httpserver
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readEvent returns the next SSE block, skipping the retry hint.
+func readEvent(t *testing.T, br *bufio.Reader) string {
+	t.Helper()
+	for {
+		var lines []string
+		for {
+			line, err := br.ReadString('\n')
+			require.NoError(t, err)
+			line = strings.TrimSuffix(line, "\n")
+			if line == "" {
+				break
+			}
+			lines = append(lines, line)
+		}
+		if block := strings.Join(lines, "\n"); !strings.HasPrefix(block, "retry:") {
+			return block
+		}
+	}
+}
+
+func TestStreamQuotes(t *testing.T) {
+	qr := &fakeQuoteRepo{store: map[string]domain.Quote{}}
+	ts0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, qr.AppendHistory(context.Background(), domain.QuoteHistory{Pair: "EUR/USD", Price: 1.1, QuotedAt: ts0}))
+	require.NoError(t, qr.AppendHistory(context.Background(), domain.QuoteHistory{Pair: "EUR/USD", Price: 1.2, QuotedAt: ts0}))
+	feed := &fakeQuoteFeed{}
+	svc := application.NewService(qr, &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}, fakeRateProvider{},
+		redisstore.NoopIdempotency{}, application.WithQuoteFeed(feed))
+	srv := NewServer(svc)
+	srv.SetStreamHeartbeat(50 * time.Millisecond)
+	ts := httptest.NewServer(NewRouter(srv))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/quotes/stream?pairs=EUR/USD", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "1")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	br := bufio.NewReader(resp.Body)
+
+	ev := readEvent(t, br)
+	require.Contains(t, ev, "id: 2\nevent: quote\ndata: ")
+	require.Contains(t, ev, `"price":1.2`)
+
+	require.Eventually(t, func() bool { return feed.subscribers() == 1 }, time.Second, 5*time.Millisecond)
+	feed.publish(domain.QuoteHistory{ID: 3, Pair: "USD/MXN", Price: 17, QuotedAt: ts0})
+	feed.publish(domain.QuoteHistory{ID: 4, Pair: "EUR/USD", Price: 1.3, QuotedAt: ts0})
+	ev = readEvent(t, br)
+	require.Contains(t, ev, "id: 4\n")
+	require.Contains(t, ev, `"pair":"EUR/USD"`)
+
+	require.Equal(t, ": heartbeat", readEvent(t, br))
+}
+
+func TestStreamQuotes_Errors(t *testing.T) {
+	svc, _, _, _ := NewInMemoryService()
+	h := NewRouter(NewServer(svc))
+	get := func(url string) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec.Code
+	}
+	require.Equal(t, http.StatusBadRequest, get("/quotes/stream?pairs=EURUSD"))
+	// NewInMemoryService has no quote feed.
+	require.Equal(t, http.StatusServiceUnavailable, get("/quotes/stream"))
+}
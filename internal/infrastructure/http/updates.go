@@ -0,0 +1,119 @@
+package httpserver
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/http/openapi"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultUpdatesLimit = 50
+	maxUpdatesLimit     = 500
+)
+
+var errBadCursor = errors.New("invalid cursor")
+
+// encodeCursor renders a keyset position as an opaque token.
+func encodeCursor(c domain.QuoteUpdateCursor) string {
+	raw := strconv.FormatInt(c.RequestedAt.UnixNano(), 10) + "|" + c.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(s string) (domain.QuoteUpdateCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return domain.QuoteUpdateCursor{}, errBadCursor
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return domain.QuoteUpdateCursor{}, errBadCursor
+	}
+	// Update ids are UUIDs; anything else would only fail later, in the query.
+	if _, err := uuid.Parse(id); err != nil {
+		return domain.QuoteUpdateCursor{}, errBadCursor
+	}
+	ns, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return domain.QuoteUpdateCursor{}, errBadCursor
+	}
+	return domain.QuoteUpdateCursor{RequestedAt: time.Unix(0, ns).UTC(), ID: id}, nil
+}
+
+func (s *Server) ListQuoteUpdates(w http.ResponseWriter, r *http.Request, params openapi.ListQuoteUpdatesParams) {
+	log := loggerForRequest(r)
+	f := domain.QuoteUpdateFilter{Limit: defaultUpdatesLimit}
+	if params.Status != nil {
+		switch st := domain.QuoteUpdateStatus(*params.Status); st {
+		case domain.QuoteUpdateStatusQueued, domain.QuoteUpdateStatusProcessing,
+			domain.QuoteUpdateStatusDone, domain.QuoteUpdateStatusFailed, domain.QuoteUpdateStatusCanceled:
+			f.Status = st
+		default:
+			writeProblem(w, r, problemValidationFailed, "", fieldError("status", "must be one of queued, processing, done, failed, canceled"))
+			return
+		}
+	}
+	if params.Pair != nil {
+		if !domain.ValidatePair(*params.Pair) {
+			log.Warn("list_quote_updates.invalid_pair_format", zap.String("pair", *params.Pair))
+			writePairProblem(w, r, "pair", *params.Pair)
+			return
+		}
+		f.Pair = domain.Pair(*params.Pair)
+	}
+	if params.From != nil {
+		f.From = *params.From
+	}
+	if params.To != nil {
+		f.To = *params.To
+	}
+	if params.Limit != nil {
+		if *params.Limit < 1 || *params.Limit > maxUpdatesLimit {
+			writeProblem(w, r, problemValidationFailed, "", fieldError("limit", fmt.Sprintf("must be between 1 and %d", maxUpdatesLimit)))
+			return
+		}
+		f.Limit = *params.Limit
+	}
+	if params.Cursor != nil {
+		c, err := decodeCursor(*params.Cursor)
+		if err != nil {
+			writeProblem(w, r, problemValidationFailed, "", fieldError("cursor", "is not a next_cursor returned by this endpoint"))
+			return
+		}
+		f.After = &c
+	}
+	page, err := s.svc.ListQuoteUpdates(r.Context(), f)
+	if err != nil {
+		logRequestError(r, "list quote updates failed", err)
+		writeProblem(w, r, problemInternal, "")
+		return
+	}
+	resp := openapi.QuoteUpdateList{
+		Items: make([]openapi.QuoteUpdateDetails, 0, len(page.Items)),
+		Counts: openapi.QuoteUpdateStatusCounts{
+			Queued:     page.Counts[domain.QuoteUpdateStatusQueued],
+			Processing: page.Counts[domain.QuoteUpdateStatusProcessing],
+			Done:       page.Counts[domain.QuoteUpdateStatusDone],
+			Failed:     page.Counts[domain.QuoteUpdateStatusFailed],
+			Canceled:   page.Counts[domain.QuoteUpdateStatusCanceled],
+		},
+	}
+	for _, u := range page.Items {
+		resp.Items = append(resp.Items, toQuoteUpdateDetails(u))
+	}
+	if page.Next != nil {
+		next := encodeCursor(*page.Next)
+		resp.NextCursor = &next
+	}
+	log.Info("list_quote_updates.success", zap.Int("count", len(resp.Items)))
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,84 @@
+package httpserver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+
+	"github.com/stretchr/testify/require"
+)
+
+type updateListResp struct {
+	Items []struct {
+		UpdateID string `json:"update_id"`
+		Status   string `json:"status"`
+	} `json:"items"`
+	NextCursor *string          `json:"next_cursor"`
+	Counts     map[string]int64 `json:"counts"`
+}
+
+func TestListQuoteUpdates_FilterAndPaginate(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	uid := func(i int) string { return fmt.Sprintf("00000000-0000-4000-8000-%012d", i) }
+	ur := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{}}
+	for i := 0; i < 5; i++ {
+		st := domain.QuoteUpdateStatusQueued
+		if i%2 == 1 {
+			st = domain.QuoteUpdateStatusFailed
+		}
+		id := uid(i)
+		ur.jobs[id] = domain.QuoteUpdate{ID: id, Pair: "EUR/USD", Status: st, RequestedAt: base.Add(time.Duration(i) * time.Minute)}
+	}
+	ur.jobs[uid(99)] = domain.QuoteUpdate{ID: uid(99), Pair: "USD/MXN", Status: domain.QuoteUpdateStatusDone, RequestedAt: base}
+	svc := application.NewService(&fakeQuoteRepo{}, ur, fakeRateProvider{}, redisstore.NoopIdempotency{})
+	h := NewRouter(NewServer(svc))
+
+	get := func(q url.Values) updateListResp {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/quotes/updates?"+q.Encode(), nil))
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var out updateListResp
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+		return out
+	}
+
+	q := url.Values{"pair": {"EUR/USD"}, "limit": {"2"}}
+	var seen []string
+	for {
+		page := get(q)
+		require.Equal(t, map[string]int64{"queued": 3, "processing": 0, "done": 0, "failed": 2, "canceled": 0}, page.Counts)
+		for _, it := range page.Items {
+			seen = append(seen, it.UpdateID)
+		}
+		if page.NextCursor == nil {
+			break
+		}
+		q.Set("cursor", *page.NextCursor)
+	}
+	require.Equal(t, []string{uid(4), uid(3), uid(2), uid(1), uid(0)}, seen)
+
+	failed := get(url.Values{"status": {"failed"}, "from": {base.Add(2 * time.Minute).Format(time.RFC3339)}})
+	require.Len(t, failed.Items, 1)
+	require.Equal(t, uid(3), failed.Items[0].UpdateID)
+}
+
+func TestListQuoteUpdates_BadParams(t *testing.T) {
+	svc, _, _, _ := NewInMemoryService()
+	h := NewRouter(NewServer(svc))
+	// The last cursor is well-formed except that its id is not a UUID.
+	badID := base64.RawURLEncoding.EncodeToString([]byte("1735689600000000000|nope"))
+	for _, q := range []string{"status=bogus", "limit=0", "cursor=bm9wZQ", "pair=XXX", "cursor=" + badID} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/quotes/updates?"+q, nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code, q)
+	}
+}
@@ -0,0 +1,49 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetQuoteUpdate_Wait(t *testing.T) {
+	svc, _, ur, _ := NewInMemoryService()
+	ur.jobs["q"] = domain.QuoteUpdate{ID: "q", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusQueued}
+	ur.jobs["d"] = domain.QuoteUpdate{ID: "d", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusDone}
+	h := NewRouter(NewServer(svc))
+
+	get := func(url string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, url, nil))
+		return rec
+	}
+	status := func(rec *httptest.ResponseRecorder) string {
+		var got struct {
+			Status string `json:"status"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		return got.Status
+	}
+
+	start := time.Now()
+	rec := get("/quotes/updates/d?wait=10s")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "done", status(rec))
+	require.Less(t, time.Since(start), time.Second)
+
+	start = time.Now()
+	rec = get("/quotes/updates/q?wait=50ms")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.Equal(t, "queued", status(rec))
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	require.Equal(t, http.StatusBadRequest, get("/quotes/updates/q?wait=soon").Code)
+	require.Equal(t, http.StatusBadRequest, get("/quotes/updates/q?wait=-1s").Code)
+	require.Equal(t, http.StatusNotFound, get("/quotes/updates/missing?wait=1s").Code)
+}
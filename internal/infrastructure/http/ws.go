@@ -0,0 +1,380 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/http/openapi"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// wsSendBuffer bounds the messages queued for one connection; a client that lets it
+	// fill up is disconnected rather than slowing down the feed.
+	wsSendBuffer      = 64
+	wsWriteTimeout    = 10 * time.Second
+	wsMaxMessageBytes = 16 << 10
+	maxWSUpdates      = 100
+)
+
+// wsRequest is a client message: {"type":"subscribe"|"unsubscribe","pairs":[...],"updates":[...]}.
+type wsRequest struct {
+	Type    string   `json:"type"`
+	Pairs   []string `json:"pairs,omitempty"`
+	Updates []string `json:"updates,omitempty"`
+}
+
+// wsMessage is a server message; Type is one of subscribed, quote, update, error or heartbeat.
+type wsMessage struct {
+	Type     string                      `json:"type"`
+	ID       int64                       `json:"id,omitempty"`
+	Quote    *openapi.LastQuote          `json:"quote,omitempty"`
+	UpdateID string                      `json:"update_id,omitempty"`
+	Update   *openapi.QuoteUpdateDetails `json:"update,omitempty"`
+	Pairs    []string                    `json:"pairs,omitempty"`
+	Updates  []string                    `json:"updates,omitempty"`
+	Error    string                      `json:"error,omitempty"`
+}
+
+var errWSOrigin = errors.New("websocket origin not allowed")
+
+// SetWSAllowedOrigins lists the origins (scheme://host[:port]) other than the API's own
+// that may open /ws; "*" allows any. Blank entries are ignored.
+func (s *Server) SetWSAllowedOrigins(origins ...string) {
+	s.wsOrigins = nil
+	for _, o := range origins {
+		if o = strings.TrimSpace(o); o != "" {
+			s.wsOrigins = append(s.wsOrigins, strings.TrimSuffix(o, "/"))
+		}
+	}
+}
+
+// checkWSOrigin rejects cross-origin handshakes unless the origin is allowed, so a page
+// on another site cannot ride on a browser's credentials. Requests without Origin come
+// from non-browser clients (trading tools) and are accepted.
+func (s *Server) checkWSOrigin(r *http.Request) error {
+	origin := r.Header.Get("Origin")
+	if origin == "" || slices.Contains(s.wsOrigins, "*") || slices.Contains(s.wsOrigins, origin) {
+		return nil
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+		return nil
+	}
+	return errWSOrigin
+}
+
+// ServeWS upgrades to a WebSocket that multiplexes quote and update-status subscriptions.
+func (s *Server) ServeWS(w http.ResponseWriter, r *http.Request) {
+	ws := websocket.Server{
+		Handshake: func(_ *websocket.Config, r *http.Request) error {
+			if err := s.checkWSOrigin(r); err != nil {
+				loggerForRequest(r).Warn("ws.origin_rejected", zap.String("origin", r.Header.Get("Origin")))
+				return err
+			}
+			return nil
+		},
+		Handler: func(conn *websocket.Conn) { s.serveWS(r, conn) },
+	}
+	ws.ServeHTTP(w, r)
+}
+
+type wsSession struct {
+	s      *Server
+	conn   *websocket.Conn
+	log    *zap.Logger
+	ctx    context.Context
+	cancel context.CancelFunc
+	out    chan wsMessage
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	pairs    map[domain.Pair]bool
+	updates  map[string]context.CancelFunc
+	watching bool
+	slow     bool
+}
+
+func (s *Server) serveWS(r *http.Request, conn *websocket.Conn) {
+	ctx, cancel := context.WithCancel(r.Context())
+	c := &wsSession{
+		s:       s,
+		conn:    conn,
+		log:     loggerForRequest(r),
+		ctx:     ctx,
+		cancel:  cancel,
+		out:     make(chan wsMessage, wsSendBuffer),
+		pairs:   map[domain.Pair]bool{},
+		updates: map[string]context.CancelFunc{},
+	}
+	conn.MaxPayloadBytes = wsMaxMessageBytes
+	c.log.Info("ws.open")
+
+	c.wg.Add(2)
+	go c.writeLoop()
+	go func() {
+		defer c.wg.Done()
+		select {
+		case <-ctx.Done():
+		case <-s.closing:
+			cancel()
+		}
+		// Unblocks the read loop below.
+		_ = conn.Close()
+	}()
+
+	for {
+		var data []byte
+		if err := websocket.Message.Receive(conn, &data); err != nil {
+			break
+		}
+		var req wsRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			c.send(wsMessage{Type: "error", Error: "invalid JSON message"})
+			continue
+		}
+		c.handle(req)
+	}
+	cancel()
+	c.wg.Wait()
+	c.log.Info("ws.closed", zap.Bool("slow_consumer", c.slow))
+}
+
+func (c *wsSession) writeLoop() {
+	defer c.wg.Done()
+	defer c.cancel()
+	heartbeat := time.NewTicker(c.s.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		var msg wsMessage
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-heartbeat.C:
+			msg = wsMessage{Type: "heartbeat"}
+		case msg = <-c.out:
+		}
+		_ = c.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		if err := websocket.JSON.Send(c.conn, msg); err != nil {
+			return
+		}
+	}
+}
+
+// send queues msg without blocking; when the queue is full the connection is dropped.
+func (c *wsSession) send(msg wsMessage) {
+	select {
+	case c.out <- msg:
+		return
+	default:
+	}
+	c.mu.Lock()
+	first := !c.slow
+	c.slow = true
+	c.mu.Unlock()
+	if first {
+		c.log.Warn("ws.slow_consumer", zap.Int("buffer", wsSendBuffer))
+	}
+	c.cancel()
+}
+
+func (c *wsSession) handle(req wsRequest) {
+	switch req.Type {
+	case "subscribe":
+		c.subscribe(req)
+	case "unsubscribe":
+		c.unsubscribe(req)
+	default:
+		c.send(wsMessage{Type: "error", Error: "unknown message type"})
+	}
+}
+
+func (c *wsSession) subscribe(req wsRequest) {
+	for _, p := range req.Pairs {
+		if !domain.ValidatePair(p) {
+			c.send(wsMessage{Type: "error", Error: "invalid pair", Pairs: []string{p}})
+			return
+		}
+	}
+	c.mu.Lock()
+	pairs := len(c.pairs)
+	for _, p := range req.Pairs {
+		if !c.pairs[domain.Pair(p)] {
+			pairs++
+		}
+	}
+	updates := len(c.updates) + len(req.Updates)
+	watching := c.watching
+	c.mu.Unlock()
+	if pairs > maxLatestPairs {
+		c.send(wsMessage{Type: "error", Error: "too many pairs"})
+		return
+	}
+	if updates > maxWSUpdates {
+		c.send(wsMessage{Type: "error", Error: "too many updates"})
+		return
+	}
+
+	var quotes <-chan domain.QuoteHistory
+	if len(req.Pairs) > 0 && !watching {
+		var err error
+		if quotes, err = c.s.svc.WatchQuotes(c.ctx, nil, nil); err != nil {
+			if errors.Is(err, application.ErrQuoteFeedUnavailable) {
+				c.send(wsMessage{Type: "error", Error: "quote streaming unavailable"})
+				return
+			}
+			c.log.Error("ws.watch_quotes_failed", zap.Error(err))
+			c.send(wsMessage{Type: "error", Error: "internal error"})
+			return
+		}
+	}
+
+	type watch struct {
+		id     string
+		ch     <-chan domain.QuoteUpdate
+		ctx    context.Context
+		cancel context.CancelFunc
+	}
+	var watches []watch
+	for _, id := range req.Updates {
+		c.mu.Lock()
+		_, dup := c.updates[id]
+		c.mu.Unlock()
+		if dup || slices.ContainsFunc(watches, func(w watch) bool { return w.id == id }) {
+			continue
+		}
+		ctx, cancel := context.WithCancel(c.ctx)
+		ch, err := c.s.svc.WatchQuoteUpdate(ctx, id)
+		if err != nil {
+			cancel()
+			if errors.Is(err, domain.ErrNotFound) {
+				c.send(wsMessage{Type: "error", Error: "update not found", Updates: []string{id}})
+			} else {
+				c.log.Error("ws.watch_update_failed", zap.String("update_id", id), zap.Error(err))
+				c.send(wsMessage{Type: "error", Error: "internal error", Updates: []string{id}})
+			}
+			continue
+		}
+		watches = append(watches, watch{id: id, ch: ch, ctx: ctx, cancel: cancel})
+	}
+
+	c.mu.Lock()
+	for _, p := range req.Pairs {
+		c.pairs[domain.Pair(p)] = true
+	}
+	for _, w := range watches {
+		c.updates[w.id] = w.cancel
+	}
+	if quotes != nil {
+		c.watching = true
+	}
+	c.mu.Unlock()
+	// Acknowledge before any event so clients see their subscriptions first.
+	c.ack()
+
+	if quotes != nil {
+		c.wg.Add(1)
+		go c.forwardQuotes(quotes)
+	}
+	for _, w := range watches {
+		c.wg.Add(1)
+		go c.forwardUpdate(w.ctx, w.id, w.ch, w.cancel)
+	}
+}
+
+func (c *wsSession) unsubscribe(req wsRequest) {
+	c.mu.Lock()
+	for _, p := range req.Pairs {
+		delete(c.pairs, domain.Pair(p))
+	}
+	for _, id := range req.Updates {
+		if cancel, ok := c.updates[id]; ok {
+			cancel()
+			delete(c.updates, id)
+		}
+	}
+	c.mu.Unlock()
+	c.ack()
+}
+
+func (c *wsSession) ack() {
+	c.mu.Lock()
+	msg := wsMessage{Type: "subscribed", Pairs: []string{}, Updates: []string{}}
+	for p := range c.pairs {
+		msg.Pairs = append(msg.Pairs, string(p))
+	}
+	for id := range c.updates {
+		msg.Updates = append(msg.Updates, id)
+	}
+	c.mu.Unlock()
+	slices.Sort(msg.Pairs)
+	slices.Sort(msg.Updates)
+	c.send(msg)
+}
+
+// forwardQuotes relays every stored quote change of a subscribed pair. Quotes are watched
+// for all pairs once, so subscribing to more pairs later needs no new feed subscription.
+func (c *wsSession) forwardQuotes(quotes <-chan domain.QuoteHistory) {
+	defer c.wg.Done()
+	var last int64
+	for {
+		for h := range quotes {
+			last = h.ID
+			c.mu.Lock()
+			want := c.pairs[h.Pair]
+			c.mu.Unlock()
+			if want {
+				q := toLastQuote(domain.Quote{Pair: h.Pair, Price: h.Price, UpdatedAt: h.QuotedAt})
+				c.send(wsMessage{Type: "quote", ID: h.ID, Quote: &q})
+			}
+		}
+		if c.ctx.Err() != nil {
+			return
+		}
+		// The feed dropped us; resume from the history so nothing is skipped.
+		var after *int64
+		if last > 0 {
+			after = &last
+		}
+		var err error
+		if quotes, err = c.s.svc.WatchQuotes(c.ctx, nil, after); err != nil {
+			c.log.Error("ws.watch_quotes_failed", zap.Error(err))
+			c.send(wsMessage{Type: "error", Error: "quote stream interrupted"})
+			c.cancel()
+			return
+		}
+	}
+}
+
+// forwardUpdate relays status changes of one update; the subscription ends by itself
+// once the update reaches a terminal status.
+func (c *wsSession) forwardUpdate(ctx context.Context, id string, ch <-chan domain.QuoteUpdate, cancel context.CancelFunc) {
+	defer c.wg.Done()
+	defer cancel()
+	var last domain.QuoteUpdate
+	for upd := range ch {
+		last = upd
+		d := toQuoteUpdateDetails(upd)
+		c.send(wsMessage{Type: "update", UpdateID: id, Update: &d})
+	}
+	c.mu.Lock()
+	// A canceled watch was already removed by unsubscribe and may have been replaced.
+	ended := ctx.Err() == nil
+	if ended {
+		delete(c.updates, id)
+	}
+	c.mu.Unlock()
+	if ended && !last.Status.Terminal() {
+		c.send(wsMessage{Type: "error", Error: "update watch ended", Updates: []string{id}})
+	}
+}
@@ -0,0 +1,136 @@
+package httpserver
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+// readWS returns the next non-heartbeat message.
+func readWS(t *testing.T, conn *websocket.Conn) wsMessage {
+	t.Helper()
+	for {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(2*time.Second)))
+		var msg wsMessage
+		require.NoError(t, websocket.JSON.Receive(conn, &msg))
+		if msg.Type != "heartbeat" {
+			return msg
+		}
+	}
+}
+
+func TestServeWS(t *testing.T) {
+	feed := &fakeQuoteFeed{}
+	jobs := &fakeUpdateJobRepo{jobs: map[string]domain.QuoteUpdate{
+		"u1": {ID: "u1", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusDone},
+	}}
+	svc := application.NewService(&fakeQuoteRepo{store: map[string]domain.Quote{}}, jobs, fakeRateProvider{},
+		redisstore.NoopIdempotency{}, application.WithQuoteFeed(feed))
+	srv := NewServer(svc)
+	srv.SetStreamHeartbeat(50 * time.Millisecond)
+	ts := httptest.NewServer(NewRouter(srv))
+	defer ts.Close()
+
+	conn, err := websocket.Dial("ws"+strings.TrimPrefix(ts.URL, "http")+"/ws", "", ts.URL)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, websocket.JSON.Send(conn, wsRequest{Type: "subscribe", Pairs: []string{"EUR/USD"}, Updates: []string{"u1"}}))
+	ack := readWS(t, conn)
+	require.Equal(t, "subscribed", ack.Type)
+	require.Equal(t, []string{"EUR/USD"}, ack.Pairs)
+	require.Equal(t, []string{"u1"}, ack.Updates)
+	upd := readWS(t, conn)
+	require.Equal(t, "update", upd.Type)
+	require.Equal(t, "u1", upd.UpdateID)
+	require.EqualValues(t, domain.QuoteUpdateStatusDone, upd.Update.Status)
+
+	require.Eventually(t, func() bool { return feed.subscribers() == 1 }, time.Second, 5*time.Millisecond)
+	ts0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	feed.publish(domain.QuoteHistory{ID: 1, Pair: "EUR/USD", Price: 1.1, QuotedAt: ts0})
+	feed.publish(domain.QuoteHistory{ID: 2, Pair: "USD/MXN", Price: 17, QuotedAt: ts0})
+	feed.publish(domain.QuoteHistory{ID: 3, Pair: "EUR/USD", Price: 1.2, QuotedAt: ts0})
+	for _, want := range []int64{1, 3} {
+		q := readWS(t, conn)
+		require.Equal(t, "quote", q.Type)
+		require.Equal(t, want, q.ID)
+		require.Equal(t, "EUR/USD", q.Quote.Pair)
+	}
+
+	// The terminal update ended its subscription; unsubscribing the pair leaves nothing.
+	require.NoError(t, websocket.JSON.Send(conn, wsRequest{Type: "unsubscribe", Pairs: []string{"EUR/USD"}}))
+	ack = readWS(t, conn)
+	require.Equal(t, "subscribed", ack.Type)
+	require.Empty(t, ack.Pairs)
+	require.Empty(t, ack.Updates)
+
+	for _, tc := range []struct {
+		send string
+		want string
+	}{
+		{`{"type":"subscribe","pairs":["EURUSD"]}`, "invalid pair"},
+		{`{"type":"subscribe","updates":["missing"]}`, "update not found"},
+		{`{"type":"ping"}`, "unknown message type"},
+		{`not json`, "invalid JSON message"},
+	} {
+		require.NoError(t, websocket.Message.Send(conn, tc.send))
+		msg := readWS(t, conn)
+		require.Equal(t, "error", msg.Type, tc.send)
+		require.Equal(t, tc.want, msg.Error, tc.send)
+		if msg.Error == "update not found" {
+			// A failed update subscription is still acknowledged, without the id.
+			require.Equal(t, "subscribed", readWS(t, conn).Type)
+		}
+	}
+}
+
+func TestWSSession_DropsSlowConsumer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := &wsSession{log: zap.NewNop(), ctx: ctx, cancel: cancel, out: make(chan wsMessage, 1)}
+
+	c.send(wsMessage{Type: "heartbeat"})
+	require.NoError(t, ctx.Err())
+	c.send(wsMessage{Type: "heartbeat"})
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+	require.True(t, c.slow)
+}
+
+func TestServeWS_Origin(t *testing.T) {
+	svc, _, _, _ := NewInMemoryService()
+	srv := NewServer(svc)
+	ts := httptest.NewServer(NewRouter(srv))
+	defer ts.Close()
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws"
+
+	_, err := websocket.Dial(wsURL, "", "https://evil.example")
+	require.Error(t, err, "cross-origin handshakes are refused by default")
+
+	srv.SetWSAllowedOrigins(" https://desk.example ", "")
+	conn, err := websocket.Dial(wsURL, "", "https://desk.example")
+	require.NoError(t, err)
+	conn.Close()
+	_, err = websocket.Dial(wsURL, "", "https://evil.example")
+	require.Error(t, err)
+
+	// Non-browser clients send no Origin at all.
+	req := httptest.NewRequest("GET", "/ws", nil)
+	require.NoError(t, srv.checkWSOrigin(req))
+	req.Header.Set("Origin", "http://"+req.Host)
+	require.NoError(t, srv.checkWSOrigin(req))
+
+	srv.SetWSAllowedOrigins("*")
+	conn, err = websocket.Dial(wsURL, "", "https://evil.example")
+	require.NoError(t, err)
+	conn.Close()
+}
@@ -0,0 +1,146 @@
+package httpx
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// DefaultValidatorCacheSize bounds a ValidatorCache created with a non-positive size.
+const DefaultValidatorCacheSize = 256
+
+// ValidatorCache keeps, per URL, the body of the most recent 200 OK response to a GET
+// together with its ETag and Last-Modified validators. It is an LRU bounded at the
+// maxEntries given to NewValidatorCache. Entries are keyed by a SHA-256 hash of the URL,
+// so provider keys in query strings are not kept in memory.
+type ValidatorCache struct {
+	mu    sync.Mutex
+	max   int
+	order *list.List // front = most recently used
+	items map[string]*list.Element
+}
+
+type cachedResponse struct {
+	key          string
+	etag         string
+	lastModified string
+	header       http.Header
+	body         []byte
+}
+
+func NewValidatorCache(maxEntries int) *ValidatorCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultValidatorCacheSize
+	}
+	return &ValidatorCache{max: maxEntries, order: list.New(), items: make(map[string]*list.Element)}
+}
+
+func (c *ValidatorCache) get(key string) (*cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*cachedResponse), true
+}
+
+func (c *ValidatorCache) put(e *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.items[e.key]; ok {
+		el.Value = e
+		c.order.MoveToFront(el)
+		return
+	}
+	c.items[e.key] = c.order.PushFront(e)
+	for c.order.Len() > c.max {
+		last := c.order.Back()
+		c.order.Remove(last)
+		delete(c.items, last.Value.(*cachedResponse).key)
+	}
+}
+
+// Len reports the number of cached URLs.
+func (c *ValidatorCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// validatorKey identifies the resource r asks for without holding on to its URL.
+func validatorKey(r *http.Request) string {
+	sum := sha256.Sum256([]byte(r.URL.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// Conditional sends If-None-Match/If-Modified-Since for GET requests whose URL is in cache
+// and turns a 304 into a 200 carrying the cached body, so callers decode it as usual.
+// Only 200 responses with at least one validator are stored.
+func Conditional(cache *ValidatorCache) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			if r.Method != http.MethodGet {
+				return next.RoundTrip(r)
+			}
+			key := validatorKey(r)
+			cached, ok := cache.get(key)
+			if ok {
+				r = r.Clone(r.Context())
+				if cached.etag != "" {
+					r.Header.Set("If-None-Match", cached.etag)
+				}
+				if cached.lastModified != "" {
+					r.Header.Set("If-Modified-Since", cached.lastModified)
+				}
+			}
+			resp, err := next.RoundTrip(r)
+			if err != nil {
+				return resp, err
+			}
+			switch {
+			case resp.StatusCode == http.StatusNotModified && ok:
+				_, _ = io.Copy(io.Discard, resp.Body)
+				resp.Body.Close()
+				return cached.response(r), nil
+			case resp.StatusCode == http.StatusOK:
+				etag, lm := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+				if etag == "" && lm == "" {
+					return resp, nil
+				}
+				body, err := io.ReadAll(resp.Body)
+				resp.Body.Close()
+				if err != nil {
+					return nil, err
+				}
+				cache.put(&cachedResponse{key: key, etag: etag, lastModified: lm, header: resp.Header.Clone(), body: body})
+				resp.Body = io.NopCloser(bytes.NewReader(body))
+				return resp, nil
+			default:
+				return resp, nil
+			}
+		})
+	}
+}
+
+func (e *cachedResponse) response(r *http.Request) *http.Response {
+	h := e.header.Clone()
+	h.Set("Content-Length", strconv.Itoa(len(e.body)))
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        h,
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       r,
+	}
+}
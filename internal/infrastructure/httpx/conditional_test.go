@@ -0,0 +1,64 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditional_RevalidatesAndReusesBody(t *testing.T) {
+	var seen []http.Header
+	c := &Client{
+		HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+			seen = append(seen, r.Header.Clone())
+			if r.Header.Get("If-None-Match") == `"v1"` {
+				return statusResp(r, http.StatusNotModified, nil, ""), nil
+			}
+			h := http.Header{"Etag": {`"v1"`}, "Last-Modified": {"Mon, 02 Jan 2006 15:04:05 GMT"}}
+			return statusResp(r, 200, h, `{"rate":1.1}`), nil
+		})),
+		Middleware: []Middleware{Conditional(NewValidatorCache(0))},
+	}
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "http://example.com/eurofxref.xml", nil)
+		var out struct{ Rate float64 }
+		require.NoError(t, c.DoJSON(context.Background(), req, &out, fastPolicy))
+		require.Equal(t, 1.1, out.Rate)
+	}
+	require.Len(t, seen, 2)
+	require.Empty(t, seen[0].Get("If-None-Match"))
+	require.Equal(t, `"v1"`, seen[1].Get("If-None-Match"))
+	require.Equal(t, "Mon, 02 Jan 2006 15:04:05 GMT", seen[1].Get("If-Modified-Since"))
+}
+
+func TestValidatorCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewValidatorCache(2)
+	c.put(&cachedResponse{key: "a"})
+	c.put(&cachedResponse{key: "b"})
+	_, _ = c.get("a")
+	c.put(&cachedResponse{key: "c"})
+	require.Equal(t, 2, c.Len())
+	_, ok := c.get("b")
+	require.False(t, ok)
+	_, ok = c.get("a")
+	require.True(t, ok)
+}
+
+func TestValidatorCache_KeyHoldsNoSecrets(t *testing.T) {
+	cache := NewValidatorCache(0)
+	c := &Client{
+		HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+			return statusResp(r, 200, http.Header{"Etag": {`"v1"`}}, `{}`), nil
+		})),
+		Middleware: []Middleware{Conditional(cache)},
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/latest?access_key=s3cr3t-key&symbols=EUR,USD", nil)
+	var out map[string]any
+	require.NoError(t, c.DoJSON(context.Background(), req, &out, fastPolicy))
+	require.Equal(t, 1, cache.Len())
+	for key := range cache.items {
+		require.NotContains(t, key, "s3cr3t-key")
+	}
+}
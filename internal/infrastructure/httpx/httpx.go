@@ -1,75 +1,203 @@
 package httpx
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
-	"github.com/cenkalti/backoff/v4"
+	"fxrates-service/internal/infrastructure/redact"
 )
 
 type Client struct {
-	HTTP  *http.Client
-	Token string
+	HTTP *http.Client
+	// Middleware wraps the transport of HTTP in order; the first entry is outermost.
+	Middleware []Middleware
 }
 
-type BackoffConfig struct {
-	Initial time.Duration
-	Max     time.Duration
-	Total   time.Duration
+// StatusError reports a non-2xx upstream response. Body holds a truncated snippet
+// so that callers can map provider-specific error payloads.
+type StatusError struct {
+	StatusCode int
+	Body       string
 }
 
-func (c *Client) DoJSON(ctx context.Context, req *http.Request, out any, cfg *BackoffConfig) error {
-	if c.Token != "" {
-		req.Header.Set("Authorization", "Bearer "+c.Token)
+func (e *StatusError) Error() string {
+	kind := "status"
+	if e.StatusCode >= 500 {
+		kind = "server error"
 	}
+	if e.Body != "" {
+		return fmt.Sprintf("%s %d: %s", kind, e.StatusCode, e.Body)
+	}
+	return fmt.Sprintf("%s %d", kind, e.StatusCode)
+}
+
+// RequestFactory builds a fresh request for every attempt. The context carries the
+// attempt deadline and must be attached to the returned request.
+type RequestFactory func(ctx context.Context) (*http.Request, error)
+
+// DoJSON performs req with retries and decodes a JSON response body into out.
+// Requests with a body are replayed via GetBody; see Replay.
+func (c *Client) DoJSON(ctx context.Context, req *http.Request, out any, p *RetryPolicy) error {
+	newReq, err := Replay(req)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, newReq, p, decodeJSON(out))
+}
+
+// DoXML performs req with retries and decodes an XML response body into out.
+func (c *Client) DoXML(ctx context.Context, req *http.Request, out any, p *RetryPolicy) error {
+	newReq, err := Replay(req)
+	if err != nil {
+		return err
+	}
+	return c.Do(ctx, newReq, p, func(r io.Reader) error {
+		if err := xml.NewDecoder(r).Decode(out); err != nil {
+			return fmt.Errorf("decode: %w", err)
+		}
+		return nil
+	})
+}
+
+// Do sends the request built by newReq until it succeeds with a 2xx status or the policy
+// gives up. decode reads the successful response body; nil discards it.
+func (c *Client) Do(ctx context.Context, newReq RequestFactory, p *RetryPolicy, decode func(io.Reader) error) error {
 	if c.HTTP == nil {
 		c.HTTP = http.DefaultClient
 	}
-
-	// default backoff config
-	if cfg == nil {
-		cfg = &BackoffConfig{
-			Initial: 200 * time.Millisecond,
-			Max:     1 * time.Second,
-			Total:   3 * time.Second,
+	if decode == nil {
+		decode = func(r io.Reader) error {
+			_, err := io.Copy(io.Discard, r)
+			return err
 		}
 	}
+	pol := p.withDefaults()
+	if pol.Total > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, pol.Total)
+		defer cancel()
+	}
 
-	exp := backoff.NewExponentialBackOff()
-	exp.InitialInterval = cfg.Initial
-	exp.MaxInterval = cfg.Max
-	exp.MaxElapsedTime = cfg.Total
+	for attempt := 1; ; attempt++ {
+		status, retryAfter, err := c.attempt(ctx, newReq, pol.AttemptTimeout, decode)
+		if err == nil {
+			return nil
+		}
+		rerr := &RetryError{Attempts: attempt, LastStatus: status, Err: err}
+		if ctx.Err() != nil || !retryable(status, err) {
+			return rerr
+		}
+		if pol.MaxAttempts > 0 && attempt >= pol.MaxAttempts {
+			return rerr
+		}
+		wait := pol.backoff(attempt)
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		// Waiting past the budget only delays the inevitable failure.
+		if dl, ok := ctx.Deadline(); ok && time.Until(dl) < wait {
+			return rerr
+		}
+		select {
+		case <-ctx.Done():
+			return rerr
+		case <-timeAfter(wait):
+		}
+	}
+}
 
-	op := func() error {
-		resp, err := c.HTTP.Do(req)
+// Replay returns a RequestFactory that clones req for each attempt. A body without
+// GetBody is buffered once so that every attempt sends the same bytes.
+func Replay(req *http.Request) (RequestFactory, error) {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		b, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("httpx: buffer request body: %w", err)
 		}
-		defer resp.Body.Close()
-		if resp.StatusCode >= 500 || resp.StatusCode != http.StatusOK {
-			const maxErrBody = 2048
-			b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrBody))
-			snippet := strings.TrimSpace(string(b))
-			if resp.StatusCode >= 500 {
-				if snippet != "" {
-					return fmt.Errorf("server error %d: %s", resp.StatusCode, snippet)
-				}
-				return fmt.Errorf("server error %d", resp.StatusCode)
-			}
-			if snippet != "" {
-				return backoff.Permanent(fmt.Errorf("status %d: %s", resp.StatusCode, snippet))
+		req.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(b)), nil }
+	}
+	return func(ctx context.Context) (*http.Request, error) {
+		r := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, &permanentError{fmt.Errorf("httpx: rewind request body: %w", err)}
 			}
-			return backoff.Permanent(fmt.Errorf("status %d", resp.StatusCode))
+			r.Body = body
 		}
-		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
-			return backoff.Permanent(fmt.Errorf("decode: %w", err))
+		return r, nil
+	}, nil
+}
+
+func decodeJSON(out any) func(io.Reader) error {
+	return func(r io.Reader) error {
+		if err := json.NewDecoder(r).Decode(out); err != nil {
+			return fmt.Errorf("decode: %w", err)
 		}
 		return nil
 	}
-	return backoff.Retry(op, backoff.WithContext(exp, ctx))
+}
+
+// httpClient returns HTTP with its transport wrapped by the middleware chain.
+func (c *Client) httpClient() *http.Client {
+	if len(c.Middleware) == 0 {
+		return c.HTTP
+	}
+	hc := *c.HTTP
+	hc.Transport = Chain(c.HTTP.Transport, c.Middleware...)
+	return &hc
+}
+
+// attempt builds and performs one request bounded by timeout. The response body is
+// decoded before the attempt context is released.
+func (c *Client) attempt(ctx context.Context, newReq RequestFactory, timeout time.Duration, decode func(io.Reader) error) (int, time.Duration, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	req, err := newReq(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		// Transport errors embed the request URL, which may carry an API key.
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			uerr.URL = redact.URL(uerr.URL)
+		}
+		return 0, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		const maxErrBody = 2048
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrBody))
+		serr := &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(b))}
+		var retryAfter time.Duration
+		// Only 429 and 503 define Retry-After as a hint for when to come back.
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
+		return resp.StatusCode, retryAfter, serr
+	}
+	if err := decode(resp.Body); err != nil {
+		// A body cut short by the connection or the attempt timeout is worth another
+		// try; one that arrived whole but does not parse is not.
+		if retryable(0, err) {
+			return resp.StatusCode, 0, err
+		}
+		return resp.StatusCode, 0, &permanentError{err}
+	}
+	return resp.StatusCode, 0, nil
 }
@@ -132,3 +132,42 @@ func TestDoJSON_DecodeError_NoRetry(t *testing.T) {
 		// ok
 	}
 }
+
+func TestDoJSON_StatusErrorExposesBody(t *testing.T) {
+	rt := httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: 401, Body: io.NopCloser(strings.NewReader(`{"error":true}`)), Header: make(http.Header), Request: r}, nil
+	}))
+	var out any
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	c := &Client{HTTP: rt}
+	err := c.DoJSON(context.Background(), req, &out, nil)
+	var serr *StatusError
+	if !errors.As(err, &serr) {
+		t.Fatalf("expected StatusError, got %v", err)
+	}
+	if serr.StatusCode != 401 || serr.Body != `{"error":true}` {
+		t.Fatalf("unexpected status error: %+v", serr)
+	}
+	if err.Error() != `status 401: {"error":true}` {
+		t.Fatalf("unexpected message: %q", err.Error())
+	}
+}
+
+func TestDoJSON_TransportErrorRedactsKey(t *testing.T) {
+	rt := httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+		return nil, errors.New("connection reset")
+	}))
+	var out any
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/latest?access_key=sekrit-123&symbols=USD", nil)
+	c := &Client{HTTP: rt}
+	err := c.DoJSON(context.Background(), req, &out, &RetryPolicy{Initial: time.Millisecond, Max: time.Millisecond, Total: 5 * time.Millisecond})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if strings.Contains(err.Error(), "sekrit-123") {
+		t.Fatalf("error leaks key: %v", err)
+	}
+	if !strings.Contains(err.Error(), "access_key=REDACTED") {
+		t.Fatalf("unexpected message: %v", err)
+	}
+}
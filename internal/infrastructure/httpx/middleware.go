@@ -0,0 +1,139 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/infrastructure/logx"
+	"fxrates-service/internal/infrastructure/redact"
+	"go.uber.org/zap"
+)
+
+// Middleware decorates an outbound transport.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc adapts a function to http.RoundTripper.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f RoundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// Chain wraps base with mws. The first middleware is the outermost, so it sees the
+// request first and the response last. A nil base means http.DefaultTransport.
+func Chain(base http.RoundTripper, mws ...Middleware) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	for i := len(mws) - 1; i >= 0; i-- {
+		base = mws[i](base)
+	}
+	return base
+}
+
+// setHeader returns a middleware that sets a header computed per request. An empty value
+// leaves the request untouched. The request is cloned because RoundTrippers must not
+// mutate their input.
+func setHeader(name string, value func(*http.Request) string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			v := value(r)
+			if v == "" {
+				return next.RoundTrip(r)
+			}
+			r = r.Clone(r.Context())
+			r.Header.Set(name, v)
+			return next.RoundTrip(r)
+		})
+	}
+}
+
+// BearerToken sets "Authorization: Bearer <token>" on every request.
+func BearerToken(token string) Middleware {
+	redact.Register(token)
+	return setHeader("Authorization", func(*http.Request) string {
+		if token == "" {
+			return ""
+		}
+		return "Bearer " + token
+	})
+}
+
+// UserAgent sets the User-Agent header unless the caller already did.
+func UserAgent(ua string) Middleware {
+	return setHeader("User-Agent", func(r *http.Request) string {
+		if r.Header.Get("User-Agent") != "" {
+			return ""
+		}
+		return ua
+	})
+}
+
+// ContextWithTraceID attaches a trace ID for the TraceHeader middleware. It shares its
+// key with application.ContextWithTraceID, so IDs set by the service are forwarded too.
+func ContextWithTraceID(ctx context.Context, id string) context.Context {
+	return application.ContextWithTraceID(ctx, id)
+}
+
+// TraceIDFromContext returns the trace ID set by ContextWithTraceID, if any.
+func TraceIDFromContext(ctx context.Context) string {
+	return application.TraceIDFromContext(ctx)
+}
+
+// TraceHeader forwards the context trace ID in the named header (X-Trace-Id when empty).
+func TraceHeader(header string) Middleware {
+	if header == "" {
+		header = "X-Trace-Id"
+	}
+	return setHeader(header, func(r *http.Request) string { return TraceIDFromContext(r.Context()) })
+}
+
+// RequestMetric describes one completed round trip.
+type RequestMetric struct {
+	Method   string
+	Host     string
+	Status   int // 0 on transport error
+	Duration time.Duration
+	Err      error
+}
+
+// Metrics reports the latency and outcome of every round trip to observe.
+func Metrics(observe func(RequestMetric)) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(r)
+			m := RequestMetric{Method: r.Method, Host: r.URL.Host, Duration: time.Since(start), Err: err}
+			if resp != nil {
+				m.Status = resp.StatusCode
+			}
+			observe(m)
+			return resp, err
+		})
+	}
+}
+
+// Logging writes one structured entry per round trip. The URL is redacted so provider
+// keys in query strings never reach the logs.
+func Logging() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(r)
+			fields := []zap.Field{
+				zap.String("method", r.Method),
+				zap.String("url", redact.URL(r.URL.String())),
+				zap.Int64("duration_ms", time.Since(start).Milliseconds()),
+			}
+			if tid := TraceIDFromContext(r.Context()); tid != "" {
+				fields = append(fields, zap.String("trace_id", tid))
+			}
+			if err != nil {
+				logx.L().Warn("http_client.request_failed", append(fields, zap.Error(err))...)
+				return resp, err
+			}
+			logx.L().Info("http_client.request_done", append(fields, zap.Int("status", resp.StatusCode))...)
+			return resp, err
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package httpx
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_OrderAndHeaders(t *testing.T) {
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(r)
+			})
+		}
+	}
+	var got http.Header
+	var metrics []RequestMetric
+	c := &Client{
+		HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+			got = r.Header.Clone()
+			return statusResp(r, 200, nil, `{}`), nil
+		})),
+		Middleware: []Middleware{
+			tag("outer"),
+			BearerToken("secret-token"),
+			UserAgent("fxrates-test"),
+			TraceHeader(""),
+			Metrics(func(m RequestMetric) { metrics = append(metrics, m) }),
+			tag("inner"),
+		},
+	}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/rates", nil)
+	var out map[string]any
+	require.NoError(t, c.DoJSON(ContextWithTraceID(context.Background(), "tid-1"), req, &out, fastPolicy))
+
+	require.Equal(t, []string{"outer", "inner"}, order)
+	require.Equal(t, "Bearer secret-token", got.Get("Authorization"))
+	require.Equal(t, "fxrates-test", got.Get("User-Agent"))
+	require.Equal(t, "tid-1", got.Get("X-Trace-Id"))
+	require.Len(t, metrics, 1)
+	require.Equal(t, "example.com", metrics[0].Host)
+	require.Equal(t, 200, metrics[0].Status)
+	// Middleware must not leak into the caller's request.
+	require.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestUserAgent_KeepsExplicitHeader(t *testing.T) {
+	var ua string
+	rt := Chain(rtFunc(func(r *http.Request) (*http.Response, error) {
+		ua = r.Header.Get("User-Agent")
+		return statusResp(r, 200, nil, ""), nil
+	}), UserAgent("default"))
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	req.Header.Set("User-Agent", "custom")
+	_, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	require.Equal(t, "custom", ua)
+}
@@ -0,0 +1,208 @@
+package httpx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fxrates-service/internal/infrastructure/redact"
+)
+
+// RecordMode selects how a Recorder treats outbound traffic.
+type RecordMode string
+
+const (
+	RecordOff    RecordMode = "off"
+	RecordRecord RecordMode = "record"
+	RecordReplay RecordMode = "replay"
+)
+
+const redactedValue = redact.Placeholder
+
+// ErrNoFixture is returned in replay mode for a request that was never recorded.
+var ErrNoFixture = errors.New("httpx: no fixture")
+
+// DefaultSecretParams lists query parameters that carry provider credentials.
+var DefaultSecretParams = redact.SecretParams
+
+// Recorder is an http.RoundTripper that stores request/response pairs as fixture files
+// (record mode) or serves responses from them without touching the network (replay mode).
+// Secret query parameters are scrubbed before a fixture is written or looked up,
+// so fixtures never contain keys and replay does not depend on them.
+type Recorder struct {
+	Dir  string
+	Mode RecordMode
+	// Next performs real requests in record mode; defaults to http.DefaultTransport.
+	Next http.RoundTripper
+	// SecretParams defaults to DefaultSecretParams.
+	SecretParams []string
+}
+
+// Fixture is the on-disk form of a captured exchange.
+type Fixture struct {
+	Request  FixtureRequest  `json:"request"`
+	Response FixtureResponse `json:"response"`
+}
+
+type FixtureRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type FixtureResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+func (rec *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		b, err := io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+		req.Body = io.NopCloser(bytes.NewReader(b))
+	}
+	scrubbed := rec.scrubURL(req.URL)
+	path := filepath.Join(rec.Dir, fixtureName(req.Method, scrubbed, body))
+
+	switch rec.Mode {
+	case RecordReplay:
+		return rec.replay(req, path, scrubbed)
+	case RecordRecord:
+		return rec.record(req, path, scrubbed, body)
+	default:
+		return rec.next().RoundTrip(req)
+	}
+}
+
+func (rec *Recorder) replay(req *http.Request, path string, scrubbed *url.URL) (*http.Response, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Retrying cannot make a fixture appear.
+		return nil, &permanentError{fmt.Errorf("%w for %s %s (expected %s; capture it with HTTP_RECORD_MODE=record)",
+			ErrNoFixture, req.Method, scrubbed, path)}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("httpx: fixture %s: %w", filepath.Base(path), err)
+	}
+	var fx Fixture
+	if err := json.Unmarshal(b, &fx); err != nil {
+		return nil, fmt.Errorf("httpx: fixture %s: %w", filepath.Base(path), err)
+	}
+	header := fx.Response.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fx.Response.Status, http.StatusText(fx.Response.Status)),
+		StatusCode:    fx.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(fx.Response.Body)),
+		ContentLength: int64(len(fx.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (rec *Recorder) record(req *http.Request, path string, scrubbed *url.URL, body []byte) (*http.Response, error) {
+	resp, err := rec.next().RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	fx := Fixture{
+		Request: FixtureRequest{Method: req.Method, URL: scrubbed.String(), Body: string(body)},
+		Response: FixtureResponse{
+			Status: resp.StatusCode,
+			Header: fixtureHeaders(resp.Header),
+			Body:   string(respBody),
+		},
+	}
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(fx); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(rec.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("httpx: fixtures dir: %w", err)
+	}
+	if err := os.WriteFile(path, out.Bytes(), 0o644); err != nil {
+		return nil, fmt.Errorf("httpx: write fixture: %w", err)
+	}
+	return resp, nil
+}
+
+func (rec *Recorder) next() http.RoundTripper {
+	if rec.Next != nil {
+		return rec.Next
+	}
+	return http.DefaultTransport
+}
+
+func (rec *Recorder) scrubURL(u *url.URL) *url.URL {
+	params := rec.SecretParams
+	if params == nil {
+		params = DefaultSecretParams
+	}
+	out := *u
+	q := out.Query()
+	for _, p := range params {
+		if q.Has(p) {
+			q.Set(p, redactedValue)
+		}
+	}
+	out.RawQuery = q.Encode()
+	return &out
+}
+
+// fixtureHeaders keeps only the response headers that matter for decoding and caching.
+func fixtureHeaders(h http.Header) http.Header {
+	out := make(http.Header)
+	for _, k := range []string{"Content-Type", "Etag", "Last-Modified", "Retry-After"} {
+		if v := h.Values(k); len(v) > 0 {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// fixtureName is readable (method, host, path) and unique per scrubbed request.
+func fixtureName(method string, u *url.URL, body []byte) string {
+	sum := sha256.New()
+	sum.Write([]byte(method + " " + u.String() + "\n"))
+	sum.Write(body)
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, u.Host+u.Path)
+	return fmt.Sprintf("%s_%s_%s.json", strings.ToLower(method), strings.Trim(slug, "_"), hex.EncodeToString(sum.Sum(nil))[:12])
+}
@@ -0,0 +1,75 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder_RecordThenReplay(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+	type resp struct {
+		OK bool `json:"ok"`
+	}
+
+	rec := &Recorder{Dir: dir, Mode: RecordRecord}
+	c := &Client{HTTP: &http.Client{Transport: rec}}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/latest?access_key=secret-key&symbols=EUR,USD", nil)
+	var out resp
+	if err := c.DoJSON(context.Background(), req, &out, nil); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if !out.OK || hits != 1 {
+		t.Fatalf("unexpected record result ok=%v hits=%d", out.OK, hits)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("expected one fixture, got %d", len(files))
+	}
+	data, _ := os.ReadFile(files[0])
+	if strings.Contains(string(data), "secret-key") {
+		t.Fatalf("fixture leaks access_key: %s", data)
+	}
+
+	// Replay with a different key must hit the same fixture and never reach the server.
+	replay := &Client{HTTP: &http.Client{Transport: &Recorder{Dir: dir, Mode: RecordReplay}}}
+	req2, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/latest?access_key=other-key&symbols=EUR,USD", nil)
+	var out2 resp
+	if err := replay.DoJSON(context.Background(), req2, &out2, nil); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if !out2.OK || hits != 1 {
+		t.Fatalf("unexpected replay result ok=%v hits=%d", out2.OK, hits)
+	}
+}
+
+func TestRecorder_ReplayMissingFixture(t *testing.T) {
+	c := &Client{HTTP: &http.Client{Transport: &Recorder{Dir: t.TempDir(), Mode: RecordReplay}}}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com/v1/latest?access_key=k", nil)
+	var out any
+	err := c.DoJSON(context.Background(), req, &out, &RetryPolicy{Initial: time.Millisecond, Max: time.Millisecond, Total: 10 * time.Millisecond})
+	if err == nil || !strings.Contains(err.Error(), "no fixture for GET http://example.com/v1/latest?access_key=REDACTED") {
+		t.Fatalf("expected missing fixture error, got %v", err)
+	}
+	if !errors.Is(err, ErrNoFixture) {
+		t.Fatalf("expected ErrNoFixture, got %v", err)
+	}
+	var rerr *RetryError
+	if !errors.As(err, &rerr) || rerr.Attempts != 1 {
+		t.Fatalf("a missing fixture must not be retried, got %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package httpx
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var fastPolicy = &RetryPolicy{Initial: time.Millisecond, Max: time.Millisecond, Total: 5 * time.Second}
+
+// onlyReader hides strings.Reader so http.NewRequest cannot install GetBody.
+type onlyReader struct{ io.Reader }
+
+func TestReplay_BodyResentOnRetry(t *testing.T) {
+	recordWaits(t)
+	for name, body := range map[string]io.Reader{
+		"get_body":    strings.NewReader(`{"pair":"EUR/USD"}`),
+		"no_get_body": onlyReader{strings.NewReader(`{"pair":"EUR/USD"}`)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			c := &Client{Middleware: []Middleware{BearerToken("t")}, HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+				b, _ := io.ReadAll(r.Body)
+				got = append(got, string(b))
+				require.Equal(t, "Bearer t", r.Header.Get("Authorization"))
+				if len(got) == 1 {
+					return statusResp(r, 500, nil, "boom"), nil
+				}
+				return statusResp(r, 200, nil, `{}`), nil
+			}))}
+			req, _ := http.NewRequest(http.MethodPost, "http://example.com", body)
+			var out map[string]any
+			require.NoError(t, c.DoJSON(context.Background(), req, &out, fastPolicy))
+			require.Equal(t, []string{`{"pair":"EUR/USD"}`, `{"pair":"EUR/USD"}`}, got)
+		})
+	}
+}
+
+func TestDo_FactoryPerAttemptAccepts2xx(t *testing.T) {
+	recordWaits(t)
+	var built int
+	var deadlines int
+	c := &Client{HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+		if _, ok := r.Context().Deadline(); ok {
+			deadlines++
+		}
+		if built == 1 {
+			return statusResp(r, 503, nil, ""), nil
+		}
+		return statusResp(r, http.StatusNoContent, nil, ""), nil
+	}))}
+	newReq := func(ctx context.Context) (*http.Request, error) {
+		built++
+		return http.NewRequestWithContext(ctx, http.MethodPost, "http://example.com/hook", strings.NewReader("event"))
+	}
+	pol := *fastPolicy
+	pol.AttemptTimeout = time.Second
+	require.NoError(t, c.Do(context.Background(), newReq, &pol, nil))
+	require.Equal(t, 2, built)
+	require.Equal(t, 2, deadlines)
+}
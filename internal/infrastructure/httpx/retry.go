@@ -0,0 +1,139 @@
+package httpx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how Client retries a request. Delays use exponential backoff with
+// full jitter; a Retry-After header on 429 or 503 overrides the computed delay.
+type RetryPolicy struct {
+	// Initial and Max bound the backoff delay before jitter.
+	Initial time.Duration
+	Max     time.Duration
+	// Total is the overall budget across attempts and waits.
+	Total time.Duration
+	// AttemptTimeout bounds a single attempt; zero leaves only Total.
+	AttemptTimeout time.Duration
+	// MaxAttempts caps the number of attempts; zero means limited by Total only.
+	MaxAttempts int
+}
+
+// DefaultRetryPolicy applies when a caller passes a nil policy.
+var DefaultRetryPolicy = RetryPolicy{
+	Initial: 200 * time.Millisecond,
+	Max:     1 * time.Second,
+	Total:   3 * time.Second,
+}
+
+func (p *RetryPolicy) withDefaults() RetryPolicy {
+	if p == nil {
+		return DefaultRetryPolicy
+	}
+	out := *p
+	if out.Initial <= 0 {
+		out.Initial = DefaultRetryPolicy.Initial
+	}
+	if out.Max < out.Initial {
+		out.Max = out.Initial
+	}
+	return out
+}
+
+// backoff returns the full-jitter delay before the attempt following attempt n (1-based):
+// a uniform random duration in [0, min(Max, Initial*2^(n-1))].
+func (p RetryPolicy) backoff(n int) time.Duration {
+	ceil := p.Max
+	if n <= 32 {
+		if d := p.Initial << (n - 1); d > 0 && d < ceil {
+			ceil = d
+		}
+	}
+	return jitter(ceil)
+}
+
+// RetryError is returned by Client once it gives up. It unwraps to the last attempt's
+// error, so errors.As still finds a *StatusError.
+type RetryError struct {
+	Attempts int
+	// LastStatus is the HTTP status of the last attempt, or 0 if no response arrived.
+	LastStatus int
+	Err        error
+}
+
+func (e *RetryError) Error() string {
+	if e.Attempts <= 1 {
+		return e.Err.Error()
+	}
+	if e.LastStatus == 0 {
+		return fmt.Sprintf("%v (after %d attempts)", e.Err, e.Attempts)
+	}
+	return fmt.Sprintf("%v (after %d attempts, last status %d)", e.Err, e.Attempts, e.LastStatus)
+}
+
+func (e *RetryError) Unwrap() error { return e.Err }
+
+// permanentError marks failures that retrying cannot fix, such as a malformed body.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// retryable classifies an attempt outcome: 408, 429 and 5xx responses, timeouts,
+// connection resets and truncated responses are retried; other 4xx and anything
+// unrecognised are not. A 2xx with an error failed while its body was read, so the
+// error alone decides.
+func retryable(status int, err error) bool {
+	var perm *permanentError
+	if errors.As(err, &perm) {
+		return false
+	}
+	if status != 0 && (status < 200 || status > 299) {
+		return status == http.StatusRequestTimeout || status == http.StatusTooManyRequests || status >= 500
+	}
+	var nerr net.Error
+	var operr *net.OpError
+	switch {
+	case errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.EPIPE):
+		return true
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return true
+	case errors.As(err, &nerr) && nerr.Timeout():
+		return true
+	case errors.As(err, &operr):
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter accepts delta-seconds or an HTTP date; it returns 0 when absent or invalid.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// Seams for tests.
+var (
+	jitter    = func(d time.Duration) time.Duration { return rand.N(d + 1) }
+	timeAfter = time.After
+)
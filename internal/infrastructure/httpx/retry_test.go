@@ -0,0 +1,223 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordWaits replaces the retry sleep with an immediate one and records requested delays.
+func recordWaits(t *testing.T) *[]time.Duration {
+	t.Helper()
+	var waits []time.Duration
+	prev := timeAfter
+	timeAfter = func(d time.Duration) <-chan time.Time {
+		waits = append(waits, d)
+		ch := make(chan time.Time, 1)
+		ch <- time.Now()
+		return ch
+	}
+	t.Cleanup(func() { timeAfter = prev })
+	return &waits
+}
+
+func statusResp(r *http.Request, code int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(body)), Header: header, Request: r}
+}
+
+func TestRetry_429HonoursRetryAfter(t *testing.T) {
+	waits := recordWaits(t)
+	calls := 0
+	c := &Client{HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return statusResp(r, 429, http.Header{"Retry-After": {"2"}}, "slow down"), nil
+		}
+		return statusResp(r, 200, nil, `{}`), nil
+	}))}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	var out map[string]any
+	require.NoError(t, c.DoJSON(context.Background(), req, &out, &RetryPolicy{Initial: time.Millisecond, Max: time.Millisecond, Total: 5 * time.Second}))
+	require.Equal(t, 2, calls)
+	require.Equal(t, []time.Duration{2 * time.Second}, *waits)
+}
+
+func TestRetry_500IgnoresRetryAfter(t *testing.T) {
+	waits := recordWaits(t)
+	calls := 0
+	c := &Client{HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return statusResp(r, 500, http.Header{"Retry-After": {"60"}}, "oops"), nil
+		}
+		return statusResp(r, 200, nil, `{}`), nil
+	}))}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	var out map[string]any
+	require.NoError(t, c.DoJSON(context.Background(), req, &out, &RetryPolicy{Initial: time.Millisecond, Max: time.Millisecond, Total: 5 * time.Second}))
+	require.Equal(t, 2, calls)
+	require.Len(t, *waits, 1)
+	require.LessOrEqual(t, (*waits)[0], time.Millisecond, "the backoff applies, not Retry-After")
+}
+
+func TestRetry_RetryAfterBeyondBudgetGivesUp(t *testing.T) {
+	recordWaits(t)
+	c := &Client{HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+		return statusResp(r, 503, http.Header{"Retry-After": {"60"}}, "maintenance"), nil
+	}))}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	var out any
+	err := c.DoJSON(context.Background(), req, &out, &RetryPolicy{Total: time.Second})
+	var rerr *RetryError
+	require.ErrorAs(t, err, &rerr)
+	require.Equal(t, 1, rerr.Attempts)
+	require.Equal(t, 503, rerr.LastStatus)
+}
+
+func TestRetry_4xxIsPermanent(t *testing.T) {
+	calls := 0
+	c := &Client{HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return statusResp(r, 404, nil, "nope"), nil
+	}))}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	var out any
+	err := c.DoJSON(context.Background(), req, &out, nil)
+	require.Equal(t, 1, calls)
+	var serr *StatusError
+	require.ErrorAs(t, err, &serr)
+	require.Equal(t, "status 404: nope", err.Error())
+}
+
+func TestRetry_ConnectionResetRetried(t *testing.T) {
+	recordWaits(t)
+	calls := 0
+	c := &Client{HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return nil, fmt.Errorf("read: %w", syscall.ECONNRESET)
+		}
+		return statusResp(r, 200, nil, `{}`), nil
+	}))}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	var out any
+	require.NoError(t, c.DoJSON(context.Background(), req, &out, nil))
+	require.Equal(t, 2, calls)
+}
+
+func TestRetry_BodyCutShortRetried(t *testing.T) {
+	recordWaits(t)
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// Promise more than is sent, then drop the connection mid-body.
+			conn, buf, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+			_, _ = buf.WriteString("HTTP/1.1 200 OK\r\nContent-Type: application/json\r\nContent-Length: 100\r\n\r\n{\"rate\":")
+			_ = buf.Flush()
+			_ = conn.Close()
+			return
+		}
+		_, _ = io.WriteString(w, `{"rate":1.1}`)
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	var out struct{ Rate float64 }
+	require.NoError(t, (&Client{HTTP: srv.Client()}).DoJSON(context.Background(), req, &out, nil))
+	require.Equal(t, int32(2), calls.Load())
+	require.Equal(t, 1.1, out.Rate)
+}
+
+func TestRetry_MalformedBodyIsPermanent(t *testing.T) {
+	calls := 0
+	c := &Client{HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return statusResp(r, 200, nil, `{"rate":"high"}`), nil
+	}))}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	var out struct{ Rate float64 }
+	require.Error(t, c.DoJSON(context.Background(), req, &out, nil))
+	require.Equal(t, 1, calls)
+}
+
+func TestRetry_UnknownTransportErrorIsPermanent(t *testing.T) {
+	calls := 0
+	c := &Client{HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		return nil, errors.New("tls: bad certificate")
+	}))}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	var out any
+	require.Error(t, c.DoJSON(context.Background(), req, &out, nil))
+	require.Equal(t, 1, calls)
+}
+
+func TestRetry_PerAttemptTimeout(t *testing.T) {
+	recordWaits(t)
+	calls := 0
+	c := &Client{HTTP: &http.Client{Transport: rtFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			<-r.Context().Done()
+			return nil, r.Context().Err()
+		}
+		return statusResp(r, 200, nil, `{}`), nil
+	})}}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	var out any
+	start := time.Now()
+	err := c.DoJSON(context.Background(), req, &out, &RetryPolicy{Total: 5 * time.Second, AttemptTimeout: 20 * time.Millisecond})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetry_ErrorExposesAttemptsAndStatus(t *testing.T) {
+	recordWaits(t)
+	c := &Client{HTTP: httpClientRT(rtFunc(func(r *http.Request) (*http.Response, error) {
+		return statusResp(r, 500, nil, "boom"), nil
+	}))}
+	req, _ := http.NewRequest(http.MethodGet, "http://example.com", nil)
+	var out any
+	err := c.DoJSON(context.Background(), req, &out, &RetryPolicy{Total: 5 * time.Second, MaxAttempts: 3})
+	var rerr *RetryError
+	require.ErrorAs(t, err, &rerr)
+	require.Equal(t, 3, rerr.Attempts)
+	require.Equal(t, 500, rerr.LastStatus)
+	require.Equal(t, "server error 500: boom (after 3 attempts, last status 500)", err.Error())
+}
+
+func TestRetryPolicy_FullJitterBounds(t *testing.T) {
+	prev := jitter
+	jitter = func(d time.Duration) time.Duration { return d }
+	t.Cleanup(func() { jitter = prev })
+
+	p := RetryPolicy{Initial: 100 * time.Millisecond, Max: time.Second}
+	require.Equal(t, 100*time.Millisecond, p.backoff(1))
+	require.Equal(t, 400*time.Millisecond, p.backoff(3))
+	require.Equal(t, time.Second, p.backoff(5))
+	require.Equal(t, time.Second, p.backoff(64))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, 3*time.Second, parseRetryAfter("3", now))
+	require.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).Format(http.TimeFormat), now))
+	require.Zero(t, parseRetryAfter("", now))
+	require.Zero(t, parseRetryAfter("soon", now))
+	require.Zero(t, parseRetryAfter(now.Add(-time.Minute).Format(http.TimeFormat), now))
+}
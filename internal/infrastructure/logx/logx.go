@@ -25,7 +25,7 @@ func init() {
 	}
 
 	var err error
-	logger, err = zapCfg.Build(zap.AddCaller(), zap.AddCallerSkip(0))
+	logger, err = zapCfg.Build(zap.AddCaller(), zap.AddCallerSkip(0), zap.WrapCore(Redacting))
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,52 @@
+package logx
+
+import (
+	"fmt"
+
+	"fxrates-service/internal/infrastructure/redact"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Redacting wraps a core so that messages, string fields and errors are scrubbed of
+// credentials before they are encoded.
+func Redacting(core zapcore.Core) zapcore.Core { return redactCore{core} }
+
+type redactCore struct{ zapcore.Core }
+
+func (c redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return redactCore{c.Core.With(redactFields(fields))}
+}
+
+func (c redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = redact.String(ent.Message)
+	return c.Core.Write(ent, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		switch f.Type {
+		case zapcore.StringType:
+			f.String = redact.String(f.String)
+		case zapcore.ErrorType:
+			if err, ok := f.Interface.(error); ok {
+				f = zap.String(f.Key, redact.Error(err))
+			}
+		case zapcore.StringerType:
+			if s, ok := f.Interface.(fmt.Stringer); ok {
+				f = zap.String(f.Key, redact.String(s.String()))
+			}
+		}
+		out[i] = f
+	}
+	return out
+}
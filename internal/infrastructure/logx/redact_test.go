@@ -0,0 +1,31 @@
+package logx
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedacting_ScrubsFieldsAndMessage(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := zap.New(Redacting(core)).With(zap.String("url", "https://x.test/latest?access_key=k-111"))
+
+	uerr := &url.Error{Op: "Get", URL: "https://x.test/latest?app_id=k-222", Err: errors.New("EOF")}
+	log.Warn("fetch failed token=k-333", zap.Error(uerr), zap.String("auth", "Bearer k-444"))
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	dump := entry.Message
+	for k, v := range entry.ContextMap() {
+		dump += " " + k + "=" + v.(string)
+	}
+	for _, secret := range []string{"k-111", "k-222", "k-333", "k-444"} {
+		require.False(t, strings.Contains(dump, secret), "leaked %s in %q", secret, dump)
+	}
+}
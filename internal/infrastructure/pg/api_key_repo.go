@@ -0,0 +1,135 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/logx"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type APIKeyRepo struct{ db *DB }
+
+func NewAPIKeyRepo(db *DB) *APIKeyRepo { return &APIKeyRepo{db: db} }
+
+func (r *APIKeyRepo) exec(ctx context.Context) execer {
+	if tx := txFromCtx(ctx); tx != nil {
+		return tx
+	}
+	return r.db.Pool
+}
+
+const apiKeyColumns = `id::text, name, prefix, scopes, created_at, revoked_at`
+
+func scanAPIKey(row pgx.Row) (domain.APIKey, error) {
+	var out domain.APIKey
+	var scopes []string
+	err := row.Scan(&out.ID, &out.Name, &out.Prefix, &scopes, &out.CreatedAt, &out.RevokedAt)
+	for _, s := range scopes {
+		out.Scopes = append(out.Scopes, domain.Scope(s))
+	}
+	return out, err
+}
+
+func (r *APIKeyRepo) Create(ctx context.Context, k domain.APIKey, hash string) (string, error) {
+	id := uuid.NewString()
+	const ins = `
+        INSERT INTO api_keys(id, name, prefix, key_hash, scopes, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)`
+	scopes := make([]string, len(k.Scopes))
+	for i, s := range k.Scopes {
+		scopes[i] = string(s)
+	}
+	log := logx.L().With(
+		zap.String("repo", "api_key"),
+		zap.String("operation", "Create"),
+		zap.String("sql", ins),
+		zap.String("id", id),
+		zap.String("name", k.Name),
+		zap.Strings("scopes", scopes),
+	)
+	log.Info("sql.exec_start")
+	tag, err := r.exec(ctx).Exec(ctx, ins, id, k.Name, k.Prefix, hash, scopes, k.CreatedAt)
+	if err != nil {
+		log.Error("sql.exec_failed", zap.Error(err))
+		return "", err
+	}
+	log.Info("sql.exec_success", zap.Int64("rows_affected", int64(tag.RowsAffected())))
+	return id, nil
+}
+
+func (r *APIKeyRepo) GetByHash(ctx context.Context, hash string) (domain.APIKey, error) {
+	const q = `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash=$1`
+	// The hash stays out of the logs; it is as good as the key for a lookup.
+	log := logx.L().With(
+		zap.String("repo", "api_key"),
+		zap.String("operation", "GetByHash"),
+		zap.String("sql", q),
+	)
+	log.Debug("sql.query_start")
+	out, err := scanAPIKey(r.exec(ctx).QueryRow(ctx, q, hash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Info("sql.query_no_rows")
+		return domain.APIKey{}, domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return domain.APIKey{}, err
+	}
+	log.Debug("sql.query_success", zap.String("id", out.ID))
+	return out, nil
+}
+
+func (r *APIKeyRepo) List(ctx context.Context) ([]domain.APIKey, error) {
+	const q = `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at, id`
+	log := logx.L().With(
+		zap.String("repo", "api_key"),
+		zap.String("operation", "List"),
+		zap.String("sql", q),
+	)
+	log.Info("sql.query_start")
+	rows, err := r.exec(ctx).Query(ctx, q)
+	if err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.APIKey
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			log.Error("sql.scan_failed", zap.Error(err))
+			return nil, err
+		}
+		out = append(out, k)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	log.Info("sql.query_success", zap.Int("rows", len(out)))
+	return out, nil
+}
+
+func (r *APIKeyRepo) Revoke(ctx context.Context, id string, at time.Time) (bool, error) {
+	const up = `UPDATE api_keys SET revoked_at=$2 WHERE id=$1 AND revoked_at IS NULL`
+	log := logx.L().With(
+		zap.String("repo", "api_key"),
+		zap.String("operation", "Revoke"),
+		zap.String("sql", up),
+		zap.String("id", id),
+	)
+	log.Info("sql.exec_start")
+	tag, err := r.exec(ctx).Exec(ctx, up, id, at)
+	if err != nil {
+		log.Error("sql.exec_failed", zap.Error(err))
+		return false, err
+	}
+	log.Info("sql.exec_success", zap.Int64("rows_affected", int64(tag.RowsAffected())))
+	return tag.RowsAffected() == 1, nil
+}
@@ -0,0 +1,48 @@
+package pg_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/pg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeyRepo_CreateLookupRevoke_WithContainer(t *testing.T) {
+	db, done := withPostgres(t)
+	defer done()
+
+	repo := pg.NewAPIKeyRepo(db)
+	ctx := context.Background()
+
+	id, err := repo.Create(ctx, domain.APIKey{
+		Name:      "desk",
+		Prefix:    "fxr_abcdef",
+		Scopes:    []domain.Scope{domain.ScopeQuotesRead, domain.ScopeQuotesWrite},
+		CreatedAt: time.Now().UTC(),
+	}, "hash-1")
+	require.NoError(t, err)
+
+	k, err := repo.GetByHash(ctx, "hash-1")
+	require.NoError(t, err)
+	require.Equal(t, id, k.ID)
+	require.Equal(t, []domain.Scope{domain.ScopeQuotesRead, domain.ScopeQuotesWrite}, k.Scopes)
+	require.Nil(t, k.RevokedAt)
+
+	_, err = repo.GetByHash(ctx, "other")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+
+	ok, err := repo.Revoke(ctx, id, time.Now().UTC())
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = repo.Revoke(ctx, id, time.Now().UTC())
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	all, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	require.NotNil(t, all[0].RevokedAt)
+}
@@ -0,0 +1,107 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/logx"
+
+	"go.uber.org/zap"
+)
+
+type AttemptRepo struct{ db *DB }
+
+func NewAttemptRepo(db *DB) *AttemptRepo { return &AttemptRepo{db: db} }
+
+func (r *AttemptRepo) exec(ctx context.Context) execer {
+	if tx := txFromCtx(ctx); tx != nil {
+		return tx
+	}
+	return r.db.Pool
+}
+
+func (r *AttemptRepo) Start(ctx context.Context, updateID, worker string, at time.Time) (int, error) {
+	const ins = `
+        INSERT INTO quote_update_attempts(update_id, attempt, worker, started_at)
+        SELECT $1, COALESCE(MAX(attempt), 0) + 1, $2, $3
+        FROM quote_update_attempts WHERE update_id = $1
+        RETURNING attempt`
+	log := logx.L().With(
+		zap.String("repo", "attempt"),
+		zap.String("operation", "Start"),
+		zap.String("sql", ins),
+		zap.String("update_id", updateID),
+		zap.String("worker", worker),
+	)
+	log.Info("sql.exec_start")
+	var n int
+	if err := r.exec(ctx).QueryRow(ctx, ins, updateID, worker, at).Scan(&n); err != nil {
+		log.Error("sql.exec_failed", zap.Error(err))
+		return 0, err
+	}
+	log.Info("sql.exec_success", zap.Int("attempt", n))
+	return n, nil
+}
+
+func (r *AttemptRepo) Finish(ctx context.Context, updateID string, number int, at time.Time, errMsg *string) error {
+	const up = `
+        UPDATE quote_update_attempts
+        SET finished_at=$3, error=$4
+        WHERE update_id=$1 AND attempt=$2`
+	log := logx.L().With(
+		zap.String("repo", "attempt"),
+		zap.String("operation", "Finish"),
+		zap.String("sql", up),
+		zap.String("update_id", updateID),
+		zap.Int("attempt", number),
+	)
+	log.Info("sql.exec_start")
+	tag, err := r.exec(ctx).Exec(ctx, up, updateID, number, at, errMsg)
+	if err != nil {
+		log.Error("sql.exec_failed", zap.Error(err))
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		log.Warn("sql.exec_no_rows")
+		return domain.ErrNotFound
+	}
+	log.Info("sql.exec_success", zap.Int64("rows_affected", int64(tag.RowsAffected())))
+	return nil
+}
+
+func (r *AttemptRepo) List(ctx context.Context, updateID string) ([]domain.QuoteUpdateAttempt, error) {
+	const q = `
+        SELECT attempt, worker, started_at, finished_at, error
+        FROM quote_update_attempts
+        WHERE update_id=$1
+        ORDER BY attempt`
+	log := logx.L().With(
+		zap.String("repo", "attempt"),
+		zap.String("operation", "List"),
+		zap.String("sql", q),
+		zap.String("update_id", updateID),
+	)
+	log.Info("sql.query_start")
+	rows, err := r.exec(ctx).Query(ctx, q, updateID)
+	if err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.QuoteUpdateAttempt
+	for rows.Next() {
+		var a domain.QuoteUpdateAttempt
+		if err := rows.Scan(&a.Number, &a.Worker, &a.StartedAt, &a.FinishedAt, &a.Error); err != nil {
+			log.Error("sql.scan_failed", zap.Error(err))
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	log.Info("sql.query_success", zap.Int("rows", len(out)))
+	return out, nil
+}
@@ -0,0 +1,58 @@
+package pg_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/pg"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttemptRepo_WithContainer(t *testing.T) {
+	db, done := withPostgres(t)
+	defer done()
+
+	jobs := pg.NewUpdateJobRepo(db)
+	attempts := pg.NewAttemptRepo(db)
+	ctx := context.Background()
+
+	id, err := jobs.CreateQueued(ctx, "EUR/USD", nil)
+	require.NoError(t, err)
+	msg := "boom"
+	require.NoError(t, jobs.UpdateStatus(ctx, id, domain.QuoteUpdateStatusFailed, &msg))
+
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	n, err := attempts.Start(ctx, id, "chan", now)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.NoError(t, attempts.Finish(ctx, id, n, now.Add(time.Second), &msg))
+
+	ok, err := jobs.RequeueFailed(ctx, id)
+	require.NoError(t, err)
+	require.True(t, ok)
+	got, err := jobs.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateStatusQueued, got.Status)
+	require.Nil(t, got.Error)
+	ok, err = jobs.RequeueFailed(ctx, id)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	n, err = attempts.Start(ctx, id, "db", now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	list, err := attempts.List(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.Equal(t, "chan", list[0].Worker)
+	require.Equal(t, msg, *list[0].Error)
+	require.NotNil(t, list[0].FinishedAt)
+	require.Equal(t, "db", list[1].Worker)
+	require.Nil(t, list[1].FinishedAt)
+
+	require.ErrorIs(t, attempts.Finish(ctx, id, 9, now, nil), domain.ErrNotFound)
+}
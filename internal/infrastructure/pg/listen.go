@@ -0,0 +1,49 @@
+package pg
+
+import (
+	"context"
+	"time"
+
+	"fxrates-service/internal/infrastructure/logx"
+
+	"go.uber.org/zap"
+)
+
+// listen keeps a LISTEN connection on channel until ctx is done, reconnecting after
+// failures. onConnect runs after every successful LISTEN, onNotify for every payload.
+func listen(ctx context.Context, db *DB, channel string, onConnect func(), onNotify func(payload string)) {
+	log := logx.L().With(zap.String("component", "pg_listener"), zap.String("channel", channel))
+	for {
+		err := listenOnce(ctx, db, channel, onConnect, onNotify)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warn("pg_listener.disconnected", zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func listenOnce(ctx context.Context, db *DB, channel string, onConnect func(), onNotify func(string)) error {
+	pc, err := db.Pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection stays in LISTEN mode, so it must not go back to the pool.
+	conn := pc.Hijack()
+	defer conn.Close(context.Background())
+	if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+		return err
+	}
+	onConnect()
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		onNotify(n.Payload)
+	}
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_quote_updates_batch;
+ALTER TABLE quote_updates DROP COLUMN IF EXISTS batch_id;
+DROP TABLE IF EXISTS quote_update_batches;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS quote_update_batches (
+  id          UUID PRIMARY KEY,
+  created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+ALTER TABLE quote_updates
+  ADD COLUMN IF NOT EXISTS batch_id UUID REFERENCES quote_update_batches(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_quote_updates_batch ON quote_updates(batch_id) WHERE batch_id IS NOT NULL;
@@ -0,0 +1,137 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/logx"
+
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type QuarantineRepo struct{ db *DB }
+
+func NewQuarantineRepo(db *DB) *QuarantineRepo { return &QuarantineRepo{db: db} }
+
+func (r *QuarantineRepo) exec(ctx context.Context) execer {
+	if tx := txFromCtx(ctx); tx != nil {
+		return tx
+	}
+	return r.db.Pool
+}
+
+const quarantineColumns = `id, pair, price::float8, quoted_at, source, update_id::text, reason, detail, inserted_at, released_at`
+
+func scanQuarantined(row pgx.Row) (domain.QuarantinedQuote, error) {
+	var out domain.QuarantinedQuote
+	var reason string
+	err := row.Scan(&out.ID, &out.Pair, &out.Price, &out.QuotedAt, &out.Source, &out.UpdateID,
+		&reason, &out.Detail, &out.InsertedAt, &out.ReleasedAt)
+	out.Reason = domain.QuoteRejectReason(reason)
+	return out, err
+}
+
+func (r *QuarantineRepo) Add(ctx context.Context, q domain.QuarantinedQuote) (int64, error) {
+	const ins = `
+        INSERT INTO quotes_quarantine(pair, price, quoted_at, source, update_id, reason, detail)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        RETURNING id`
+	log := logx.L().With(
+		zap.String("repo", "quarantine"),
+		zap.String("operation", "Add"),
+		zap.String("sql", ins),
+		zap.String("pair", string(q.Pair)),
+		zap.Float64("price", q.Price),
+		zap.String("reason", string(q.Reason)),
+	)
+	log.Info("sql.exec_start")
+	var id int64
+	err := r.exec(ctx).QueryRow(ctx, ins, q.Pair, q.Price, q.QuotedAt, q.Source, q.UpdateID, string(q.Reason), q.Detail).Scan(&id)
+	if err != nil {
+		log.Error("sql.exec_failed", zap.Error(err))
+		return 0, err
+	}
+	log.Info("sql.exec_success", zap.Int64("id", id))
+	return id, nil
+}
+
+func (r *QuarantineRepo) Get(ctx context.Context, id int64) (domain.QuarantinedQuote, error) {
+	const q = `SELECT ` + quarantineColumns + ` FROM quotes_quarantine WHERE id=$1`
+	log := logx.L().With(
+		zap.String("repo", "quarantine"),
+		zap.String("operation", "Get"),
+		zap.String("sql", q),
+		zap.Int64("id", id),
+	)
+	log.Info("sql.query_start")
+	out, err := scanQuarantined(r.exec(ctx).QueryRow(ctx, q, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Info("sql.query_no_rows")
+		return domain.QuarantinedQuote{}, domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return domain.QuarantinedQuote{}, err
+	}
+	log.Info("sql.query_success")
+	return out, nil
+}
+
+func (r *QuarantineRepo) ListPending(ctx context.Context, pair string, limit int) ([]domain.QuarantinedQuote, error) {
+	const q = `
+        SELECT ` + quarantineColumns + `
+        FROM quotes_quarantine
+        WHERE released_at IS NULL AND ($1 = '' OR pair = $1)
+        ORDER BY inserted_at DESC, id DESC
+        LIMIT $2`
+	log := logx.L().With(
+		zap.String("repo", "quarantine"),
+		zap.String("operation", "ListPending"),
+		zap.String("sql", q),
+		zap.String("pair", pair),
+		zap.Int("limit", limit),
+	)
+	log.Info("sql.query_start")
+	rows, err := r.exec(ctx).Query(ctx, q, pair, limit)
+	if err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.QuarantinedQuote
+	for rows.Next() {
+		item, err := scanQuarantined(rows)
+		if err != nil {
+			log.Error("sql.scan_failed", zap.Error(err))
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	log.Info("sql.query_success", zap.Int("rows", len(out)))
+	return out, nil
+}
+
+func (r *QuarantineRepo) MarkReleased(ctx context.Context, id int64, at time.Time) (bool, error) {
+	const up = `UPDATE quotes_quarantine SET released_at=$2 WHERE id=$1 AND released_at IS NULL`
+	log := logx.L().With(
+		zap.String("repo", "quarantine"),
+		zap.String("operation", "MarkReleased"),
+		zap.String("sql", up),
+		zap.Int64("id", id),
+	)
+	log.Info("sql.exec_start")
+	tag, err := r.exec(ctx).Exec(ctx, up, id, at)
+	if err != nil {
+		log.Error("sql.exec_failed", zap.Error(err))
+		return false, err
+	}
+	log.Info("sql.exec_success", zap.Int64("rows_affected", int64(tag.RowsAffected())))
+	return tag.RowsAffected() == 1, nil
+}
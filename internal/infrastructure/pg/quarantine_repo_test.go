@@ -0,0 +1,45 @@
+package pg_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/pg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuarantineRepo_AddListRelease_WithContainer(t *testing.T) {
+	db, done := withPostgres(t)
+	defer done()
+
+	repo := pg.NewQuarantineRepo(db)
+	ctx := context.Background()
+
+	id, err := repo.Add(ctx, domain.QuarantinedQuote{
+		Pair:     "EUR/USD",
+		Price:    2.5,
+		QuotedAt: time.Now().UTC(),
+		Source:   "db",
+		Reason:   domain.QuoteRejectDeviation,
+		Detail:   "too far",
+	})
+	require.NoError(t, err)
+
+	pending, err := repo.ListPending(ctx, "EUR/USD", 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, domain.QuoteRejectDeviation, pending[0].Reason)
+
+	ok, err := repo.MarkReleased(ctx, id, time.Now().UTC())
+	require.NoError(t, err)
+	require.True(t, ok)
+	ok, err = repo.MarkReleased(ctx, id, time.Now().UTC())
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	pending, err = repo.ListPending(ctx, "", 10)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
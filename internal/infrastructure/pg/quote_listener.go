@@ -0,0 +1,115 @@
+package pg
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/logx"
+
+	"go.uber.org/zap"
+)
+
+// QuoteChangesChannel is the NOTIFY channel carrying every row appended to quotes_history.
+const QuoteChangesChannel = "quote_changes"
+
+// quoteSubscriberBuffer is how far a subscriber may fall behind before it is dropped.
+const quoteSubscriberBuffer = 64
+
+// quoteListenReadyTimeout bounds how long Subscribe waits for the first LISTEN.
+const quoteListenReadyTimeout = 5 * time.Second
+
+// QuoteListener fans quote change notifications out to in-process subscribers. The
+// connection is opened on the first Subscribe and kept until Close.
+type QuoteListener struct {
+	db     *DB
+	ctx    context.Context
+	cancel context.CancelFunc
+	start  sync.Once
+	ready  chan struct{} // closed once the first LISTEN is active
+
+	mu        sync.Mutex
+	connected bool
+	subs      map[chan domain.QuoteHistory]struct{}
+}
+
+func NewQuoteListener(db *DB) *QuoteListener {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &QuoteListener{db: db, ctx: ctx, cancel: cancel, ready: make(chan struct{}), subs: map[chan domain.QuoteHistory]struct{}{}}
+}
+
+// Close stops listening.
+func (l *QuoteListener) Close() { l.cancel() }
+
+// Subscribe implements application.QuoteFeed. It returns once the listener is active,
+// since changes committed before then would never be delivered; if that takes longer
+// than quoteListenReadyTimeout the channel comes back closed, as after a reconnect.
+func (l *QuoteListener) Subscribe() (<-chan domain.QuoteHistory, func()) {
+	l.start.Do(func() { go listen(l.ctx, l.db, QuoteChangesChannel, l.reconnected, l.publish) })
+	ch := make(chan domain.QuoteHistory, quoteSubscriberBuffer)
+	l.mu.Lock()
+	l.subs[ch] = struct{}{}
+	l.mu.Unlock()
+	release := func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, ok := l.subs[ch]; ok {
+			delete(l.subs, ch)
+			close(ch)
+		}
+	}
+	t := time.NewTimer(quoteListenReadyTimeout)
+	defer t.Stop()
+	select {
+	case <-l.ready:
+	case <-t.C:
+		release()
+	case <-l.ctx.Done():
+		release()
+	}
+	return ch, release
+}
+
+// reconnected drops every subscriber after a reconnect: changes sent in between are
+// lost, and subscribers resume from quotes_history instead.
+func (l *QuoteListener) reconnected() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.connected {
+		l.connected = true
+		close(l.ready)
+		return
+	}
+	for ch := range l.subs {
+		delete(l.subs, ch)
+		close(ch)
+	}
+}
+
+func (l *QuoteListener) publish(payload string) {
+	var msg struct {
+		ID       int64     `json:"id"`
+		Pair     string    `json:"pair"`
+		Price    float64   `json:"price"`
+		QuotedAt time.Time `json:"quoted_at"`
+		Source   string    `json:"source"`
+	}
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		logx.L().Warn("quote_listener.bad_payload", zap.String("payload", payload), zap.Error(err))
+		return
+	}
+	h := domain.QuoteHistory{ID: msg.ID, Pair: domain.Pair(msg.Pair), Price: msg.Price, QuotedAt: msg.QuotedAt, Source: msg.Source}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for ch := range l.subs {
+		select {
+		case ch <- h:
+		default:
+			// Too slow: drop it rather than block everyone else.
+			delete(l.subs, ch)
+			close(ch)
+		}
+	}
+}
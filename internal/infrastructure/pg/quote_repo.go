@@ -46,6 +46,38 @@ func (r *QuoteRepo) GetLast(ctx context.Context, pair string) (domain.Quote, err
 	return out, nil
 }
 
+func (r *QuoteRepo) GetMany(ctx context.Context, pairs []string) ([]domain.Quote, error) {
+	const q = `SELECT pair, price::float8, updated_at FROM quotes WHERE pair = ANY($1)`
+	log := logx.L().With(
+		zap.String("repo", "quote"),
+		zap.String("operation", "GetMany"),
+		zap.String("sql", q),
+		zap.Strings("pairs", pairs),
+	)
+	log.Info("sql.query_start")
+	rows, err := r.exec(ctx).Query(ctx, q, pairs)
+	if err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.Quote
+	for rows.Next() {
+		var qt domain.Quote
+		if err := rows.Scan(&qt.Pair, &qt.Price, &qt.UpdatedAt); err != nil {
+			log.Error("sql.scan_failed", zap.Error(err))
+			return nil, err
+		}
+		out = append(out, qt)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	log.Info("sql.query_success", zap.Int("rows", len(out)))
+	return out, nil
+}
+
 func (r *QuoteRepo) Upsert(ctx context.Context, q domain.Quote) error {
 	const up = `
         INSERT INTO quotes(pair, price, updated_at)
@@ -71,10 +103,17 @@ func (r *QuoteRepo) Upsert(ctx context.Context, q domain.Quote) error {
 }
 
 func (r *QuoteRepo) AppendHistory(ctx context.Context, h domain.QuoteHistory) error {
+	// Inserted rows are announced on commit; duplicates are skipped and stay silent.
 	const insertHistory = `
+      WITH h AS (
         INSERT INTO quotes_history(pair, price, quoted_at, source, update_id)
         VALUES ($1, $2, $3, $4, $5)
         ON CONFLICT (pair, quoted_at, source) DO NOTHING
+        RETURNING id, pair, price, quoted_at, source
+      )
+      SELECT pg_notify('` + QuoteChangesChannel + `', json_build_object(
+        'id', id, 'pair', pair, 'price', price, 'quoted_at', quoted_at, 'source', source)::text)
+      FROM h
     `
 	log := logx.L().With(
 		zap.String("repo", "quote"),
@@ -97,3 +136,45 @@ func (r *QuoteRepo) AppendHistory(ctx context.Context, h domain.QuoteHistory) er
 	log.Info("sql.exec_success", zap.Int64("rows_affected", int64(tag.RowsAffected())))
 	return nil
 }
+
+func (r *QuoteRepo) HistorySince(ctx context.Context, afterID int64, pairs []string, limit int) ([]domain.QuoteHistory, error) {
+	const q = `
+        SELECT id, pair, price::float8, quoted_at, COALESCE(source, ''), update_id::text, inserted_at
+        FROM quotes_history
+        WHERE id > $1 AND (cardinality($2::text[]) = 0 OR pair = ANY($2))
+        ORDER BY id
+        LIMIT $3`
+	log := logx.L().With(
+		zap.String("repo", "quote"),
+		zap.String("operation", "HistorySince"),
+		zap.String("sql", q),
+		zap.Int64("after_id", afterID),
+		zap.Strings("pairs", pairs),
+		zap.Int("limit", limit),
+	)
+	if pairs == nil {
+		pairs = []string{}
+	}
+	log.Info("sql.query_start")
+	rows, err := r.exec(ctx).Query(ctx, q, afterID, pairs, limit)
+	if err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.QuoteHistory
+	for rows.Next() {
+		var h domain.QuoteHistory
+		if err := rows.Scan(&h.ID, &h.Pair, &h.Price, &h.QuotedAt, &h.Source, &h.UpdateID, &h.InsertedAt); err != nil {
+			log.Error("sql.scan_failed", zap.Error(err))
+			return nil, err
+		}
+		out = append(out, h)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	log.Info("sql.query_success", zap.Int("rows", len(out)))
+	return out, nil
+}
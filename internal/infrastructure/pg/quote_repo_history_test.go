@@ -42,3 +42,64 @@ func TestQuoteRepo_UpsertAndGetLast_WithContainer(t *testing.T) {
 	require.Equal(t, q.Pair, got.Pair)
 	require.InDelta(t, q.Price, got.Price, 1e-9)
 }
+
+func TestQuoteRepo_GetMany_WithContainer(t *testing.T) {
+	db, done := withPostgres(t)
+	defer done()
+
+	repo := pg.NewQuoteRepo(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	require.NoError(t, repo.Upsert(ctx, domain.Quote{Pair: "EUR/USD", Price: 1.1, UpdatedAt: now}))
+	require.NoError(t, repo.Upsert(ctx, domain.Quote{Pair: "USD/MXN", Price: 17.2, UpdatedAt: now}))
+
+	got, err := repo.GetMany(ctx, []string{"EUR/USD", "EUR/MXN", "USD/MXN"})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+}
+
+func TestQuoteRepo_HistorySince_WithContainer(t *testing.T) {
+	db, done := withPostgres(t)
+	defer done()
+
+	repo := pg.NewQuoteRepo(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	for i, p := range []domain.Pair{"EUR/USD", "USD/MXN", "EUR/USD"} {
+		require.NoError(t, repo.AppendHistory(ctx, domain.QuoteHistory{Pair: p, Price: 1, QuotedAt: now.Add(time.Duration(i) * time.Second), Source: "test"}))
+	}
+
+	all, err := repo.HistorySince(ctx, 0, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+
+	got, err := repo.HistorySince(ctx, all[0].ID, []string{"EUR/USD"}, 10)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, all[2].ID, got[0].ID)
+}
+
+func TestQuoteListener_WithContainer(t *testing.T) {
+	db, done := withPostgres(t)
+	defer done()
+
+	repo := pg.NewQuoteRepo(db)
+	l := pg.NewQuoteListener(db)
+	defer l.Close()
+	ctx := context.Background()
+
+	ch, release := l.Subscribe()
+	defer release()
+	// Subscribe returns once LISTEN is active, so the very first change arrives.
+	require.NoError(t, repo.AppendHistory(ctx, domain.QuoteHistory{Pair: "EUR/USD", Price: 1.25, QuotedAt: time.Now().UTC(), Source: "test"}))
+	select {
+	case got := <-ch:
+		require.Equal(t, domain.Pair("EUR/USD"), got.Pair)
+		require.Positive(t, got.ID)
+		require.Equal(t, 1.25, got.Price)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the first change after Subscribe was not delivered")
+	}
+}
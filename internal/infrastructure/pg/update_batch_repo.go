@@ -0,0 +1,111 @@
+package pg
+
+import (
+	"context"
+	"errors"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/logx"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"go.uber.org/zap"
+)
+
+type UpdateBatchRepo struct{ db *DB }
+
+func NewUpdateBatchRepo(db *DB) *UpdateBatchRepo { return &UpdateBatchRepo{db: db} }
+
+func (r *UpdateBatchRepo) exec(ctx context.Context) execer {
+	if tx := txFromCtx(ctx); tx != nil {
+		return tx
+	}
+	return r.db.Pool
+}
+
+func (r *UpdateBatchRepo) Create(ctx context.Context) (string, error) {
+	id := uuid.NewString()
+	const ins = `INSERT INTO quote_update_batches(id) VALUES ($1)`
+	log := logx.L().With(
+		zap.String("repo", "update_batch"),
+		zap.String("operation", "Create"),
+		zap.String("sql", ins),
+		zap.String("id", id),
+	)
+	log.Info("sql.exec_start")
+	if _, err := r.exec(ctx).Exec(ctx, ins, id); err != nil {
+		log.Error("sql.exec_failed", zap.Error(err))
+		return "", err
+	}
+	log.Info("sql.exec_success")
+	return id, nil
+}
+
+func (r *UpdateBatchRepo) AddQueued(ctx context.Context, batchID, pair string) (string, error) {
+	id := uuid.NewString()
+	const ins = `
+        INSERT INTO quote_updates(id, pair, status, batch_id)
+        VALUES ($1, $2, 'queued', $3)`
+	log := logx.L().With(
+		zap.String("repo", "update_batch"),
+		zap.String("operation", "AddQueued"),
+		zap.String("sql", ins),
+		zap.String("id", id),
+		zap.String("batch_id", batchID),
+		zap.String("pair", pair),
+	)
+	log.Info("sql.exec_start")
+	tag, err := r.exec(ctx).Exec(ctx, ins, id, pair, batchID)
+	if err != nil {
+		log.Error("sql.exec_failed", zap.Error(err))
+		return "", err
+	}
+	log.Info("sql.exec_success", zap.Int64("rows_affected", int64(tag.RowsAffected())))
+	return id, nil
+}
+
+func (r *UpdateBatchRepo) Get(ctx context.Context, id string) (domain.QuoteUpdateBatch, error) {
+	const qb = `SELECT id::text, created_at FROM quote_update_batches WHERE id=$1`
+	const qm = updateDetailsSelect + `
+        WHERE u.batch_id=$1
+        ORDER BY u.pair`
+	log := logx.L().With(
+		zap.String("repo", "update_batch"),
+		zap.String("operation", "Get"),
+		zap.String("sql", qm),
+		zap.String("id", id),
+	)
+	log.Info("sql.query_start")
+	var out domain.QuoteUpdateBatch
+	err := r.exec(ctx).QueryRow(ctx, qb, id).Scan(&out.ID, &out.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Info("sql.query_no_rows")
+		return domain.QuoteUpdateBatch{}, domain.ErrNotFound
+	}
+	if err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return domain.QuoteUpdateBatch{}, err
+	}
+	rows, err := r.exec(ctx).Query(ctx, qm, id)
+	if err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return domain.QuoteUpdateBatch{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var u domain.QuoteUpdate
+		var status string
+		if err := rows.Scan(&u.ID, &u.Pair, &status, &u.Error, &u.UpdatedAt, &u.Price, &u.RequestedAt); err != nil {
+			log.Error("sql.scan_failed", zap.Error(err))
+			return domain.QuoteUpdateBatch{}, err
+		}
+		u.Status = parseStatus(status)
+		out.Updates = append(out.Updates, u)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return domain.QuoteUpdateBatch{}, err
+	}
+	log.Info("sql.query_success", zap.Int("rows", len(out.Updates)))
+	return out, nil
+}
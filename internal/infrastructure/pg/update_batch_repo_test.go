@@ -0,0 +1,57 @@
+package pg_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/pg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateBatchRepo_CreateAndGet_WithContainer(t *testing.T) {
+	db, done := withPostgres(t)
+	defer done()
+
+	repo := pg.NewUpdateBatchRepo(db)
+	jobs := pg.NewUpdateJobRepo(db)
+	uow := &pg.UnitOfWork{Pool: db.Pool}
+	ctx := context.Background()
+
+	var batchID string
+	require.NoError(t, uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if batchID, err = repo.Create(ctx); err != nil {
+			return err
+		}
+		for _, p := range []string{"USD/MXN", "EUR/USD"} {
+			if _, err := repo.AddQueued(ctx, batchID, p); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	b, err := repo.Get(ctx, batchID)
+	require.NoError(t, err)
+	require.Len(t, b.Updates, 2)
+	require.Equal(t, domain.Pair("EUR/USD"), b.Updates[0].Pair)
+	require.Equal(t, domain.QuoteUpdateBatchPending, b.Status())
+	for _, u := range b.Updates {
+		require.NoError(t, jobs.UpdateStatus(ctx, u.ID, domain.QuoteUpdateStatusDone, nil))
+	}
+	b, err = repo.Get(ctx, batchID)
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateBatchDone, b.Status())
+
+	// A failing unit of work leaves no batch behind.
+	var rolledBack string
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		rolledBack, _ = repo.Create(ctx)
+		return errors.New("abort")
+	})
+	require.Error(t, err)
+	_, err = repo.Get(ctx, rolledBack)
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
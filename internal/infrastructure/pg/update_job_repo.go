@@ -3,6 +3,8 @@ package pg
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"fxrates-service/internal/domain"
 	"fxrates-service/internal/infrastructure/logx"
@@ -45,15 +47,16 @@ func (r *UpdateJobRepo) CreateQueued(ctx context.Context, pair string, _ *string
 	return id, nil
 }
 
-func (r *UpdateJobRepo) GetByID(ctx context.Context, id string) (domain.QuoteUpdate, error) {
-	const q = `
+// updateDetailsSelect reads update jobs together with the price they produced, if any.
+const updateDetailsSelect = `
         SELECT
           u.id::text,
           u.pair,
           u.status,
           u.error,
           COALESCE(h.quoted_at, u.completed_at, u.requested_at) AS updated_at,
-          h.price::float8
+          h.price::float8,
+          u.requested_at
         FROM quote_updates u
         LEFT JOIN LATERAL (
           SELECT price, quoted_at
@@ -61,7 +64,10 @@ func (r *UpdateJobRepo) GetByID(ctx context.Context, id string) (domain.QuoteUpd
           WHERE update_id = u.id
           ORDER BY quoted_at DESC
           LIMIT 1
-        ) h ON TRUE
+        ) h ON TRUE`
+
+func (r *UpdateJobRepo) GetByID(ctx context.Context, id string) (domain.QuoteUpdate, error) {
+	const q = updateDetailsSelect + `
         WHERE u.id=$1`
 	log := logx.L().With(
 		zap.String("repo", "update_job"),
@@ -74,7 +80,7 @@ func (r *UpdateJobRepo) GetByID(ctx context.Context, id string) (domain.QuoteUpd
 	var errMsg *string
 	var status string
 	var price *float64
-	err := r.exec(ctx).QueryRow(ctx, q, id).Scan(&out.ID, &out.Pair, &status, &errMsg, &out.UpdatedAt, &price)
+	err := r.exec(ctx).QueryRow(ctx, q, id).Scan(&out.ID, &out.Pair, &status, &errMsg, &out.UpdatedAt, &price, &out.RequestedAt)
 	if errors.Is(err, pgx.ErrNoRows) {
 		log.Info("sql.query_no_rows")
 		return domain.QuoteUpdate{}, domain.ErrNotFound
@@ -85,16 +91,7 @@ func (r *UpdateJobRepo) GetByID(ctx context.Context, id string) (domain.QuoteUpd
 	}
 	out.Error = errMsg
 	out.Price = price
-	switch status {
-	case "queued":
-		out.Status = domain.QuoteUpdateStatusQueued
-	case "processing":
-		out.Status = domain.QuoteUpdateStatusProcessing
-	case "done":
-		out.Status = domain.QuoteUpdateStatusDone
-	default:
-		out.Status = domain.QuoteUpdateStatusFailed
-	}
+	out.Status = parseStatus(status)
 	log.Info("sql.query_success",
 		zap.String("pair", string(out.Pair)),
 		zap.String("status", string(out.Status)),
@@ -111,15 +108,22 @@ func (r *UpdateJobRepo) UpdateStatus(ctx context.Context, id string, st domain.Q
 		s = "processing"
 	case domain.QuoteUpdateStatusDone:
 		s = "done"
+	case domain.QuoteUpdateStatusCanceled:
+		s = "canceled"
 	default:
 		s = "failed"
 	}
+	// The NOTIFY is part of the statement so it is only sent, on commit, if a row changed.
 	const up = `
+      WITH u AS (
         UPDATE quote_updates
         SET status=$2,
             error=$3,
-            completed_at = CASE WHEN $2 IN ('done','failed') THEN NOW() ELSE completed_at END
-        WHERE id=$1`
+            completed_at = CASE WHEN $2 IN ('done','failed','canceled') THEN NOW() ELSE completed_at END
+        WHERE id=$1
+        RETURNING id
+      )
+      SELECT pg_notify('` + UpdateStatusChannel + `', id::text) FROM u`
 	log := logx.L().With(
 		zap.String("repo", "update_job"),
 		zap.String("operation", "UpdateStatus"),
@@ -175,3 +179,173 @@ func (r *UpdateJobRepo) ClaimQueued(ctx context.Context, limit int) ([]struct{ I
 	}
 	return out, rows.Err()
 }
+
+// transition applies the SET clause to job id if it is in status from. It reports false when
+// the job exists in another status, and domain.ErrNotFound when it does not exist.
+func (r *UpdateJobRepo) transition(ctx context.Context, operation, from, set, id string) (bool, error) {
+	up := `WITH u AS (UPDATE quote_updates SET ` + set + ` WHERE id=$1 AND status='` + from + `' RETURNING id) ` +
+		`SELECT pg_notify('` + UpdateStatusChannel + `', id::text) FROM u`
+	log := logx.L().With(
+		zap.String("repo", "update_job"),
+		zap.String("operation", operation),
+		zap.String("sql", up),
+		zap.String("id", id),
+	)
+	log.Info("sql.exec_start")
+	tag, err := r.exec(ctx).Exec(ctx, up, id)
+	if err != nil {
+		log.Error("sql.exec_failed", zap.Error(err))
+		return false, err
+	}
+	if tag.RowsAffected() == 1 {
+		log.Info("sql.exec_success", zap.Int64("rows_affected", 1))
+		return true, nil
+	}
+	var exists bool
+	if err := r.exec(ctx).QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM quote_updates WHERE id=$1)`, id).Scan(&exists); err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return false, err
+	}
+	if !exists {
+		log.Warn("sql.exec_no_rows")
+		return false, domain.ErrNotFound
+	}
+	log.Info("sql.exec_status_mismatch", zap.String("from", from))
+	return false, nil
+}
+
+func (r *UpdateJobRepo) ClaimByID(ctx context.Context, id string) (bool, error) {
+	return r.transition(ctx, "ClaimByID", "queued", `status='processing'`, id)
+}
+
+func (r *UpdateJobRepo) CancelQueued(ctx context.Context, id string) (bool, error) {
+	return r.transition(ctx, "CancelQueued", "queued", `status='canceled', completed_at=NOW()`, id)
+}
+
+func (r *UpdateJobRepo) RequeueFailed(ctx context.Context, id string) (bool, error) {
+	return r.transition(ctx, "RequeueFailed", "failed", `status='queued', error=NULL, completed_at=NULL`, id)
+}
+
+// filterWhere renders the conditions of f shared by List and CountByStatus.
+func filterWhere(f domain.QuoteUpdateFilter, withStatus bool) (string, []any) {
+	var conds []string
+	var args []any
+	add := func(cond string, v any) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+	if withStatus && f.Status != "" {
+		add("u.status = $%d", string(f.Status))
+	}
+	if f.Pair != "" {
+		add("u.pair = $%d", string(f.Pair))
+	}
+	if !f.From.IsZero() {
+		add("u.requested_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("u.requested_at < $%d", f.To)
+	}
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+func (r *UpdateJobRepo) List(ctx context.Context, f domain.QuoteUpdateFilter) ([]domain.QuoteUpdate, error) {
+	where, args := filterWhere(f, true)
+	if f.After != nil {
+		args = append(args, f.After.RequestedAt, f.After.ID)
+		keyset := fmt.Sprintf("(u.requested_at, u.id) < ($%d, $%d::uuid)", len(args)-1, len(args))
+		if where == "" {
+			where = " WHERE " + keyset
+		} else {
+			where += " AND " + keyset
+		}
+	}
+	args = append(args, f.Limit)
+	q := updateDetailsSelect + where + fmt.Sprintf(`
+        ORDER BY u.requested_at DESC, u.id DESC
+        LIMIT $%d`, len(args))
+	log := logx.L().With(
+		zap.String("repo", "update_job"),
+		zap.String("operation", "List"),
+		zap.String("sql", q),
+		zap.String("status", string(f.Status)),
+		zap.String("pair", string(f.Pair)),
+		zap.Int("limit", f.Limit),
+	)
+	log.Info("sql.query_start")
+	rows, err := r.exec(ctx).Query(ctx, q, args...)
+	if err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	var out []domain.QuoteUpdate
+	for rows.Next() {
+		var u domain.QuoteUpdate
+		var status string
+		if err := rows.Scan(&u.ID, &u.Pair, &status, &u.Error, &u.UpdatedAt, &u.Price, &u.RequestedAt); err != nil {
+			log.Error("sql.scan_failed", zap.Error(err))
+			return nil, err
+		}
+		u.Status = parseStatus(status)
+		out = append(out, u)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	log.Info("sql.query_success", zap.Int("rows", len(out)))
+	return out, nil
+}
+
+func (r *UpdateJobRepo) CountByStatus(ctx context.Context, f domain.QuoteUpdateFilter) (map[domain.QuoteUpdateStatus]int64, error) {
+	where, args := filterWhere(f, false)
+	q := `SELECT u.status, count(*) FROM quote_updates u` + where + ` GROUP BY u.status`
+	log := logx.L().With(
+		zap.String("repo", "update_job"),
+		zap.String("operation", "CountByStatus"),
+		zap.String("sql", q),
+		zap.String("pair", string(f.Pair)),
+	)
+	log.Info("sql.query_start")
+	rows, err := r.exec(ctx).Query(ctx, q, args...)
+	if err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[domain.QuoteUpdateStatus]int64{}
+	for rows.Next() {
+		var status string
+		var n int64
+		if err := rows.Scan(&status, &n); err != nil {
+			log.Error("sql.scan_failed", zap.Error(err))
+			return nil, err
+		}
+		out[parseStatus(status)] += n
+	}
+	if err := rows.Err(); err != nil {
+		log.Error("sql.query_failed", zap.Error(err))
+		return nil, err
+	}
+	log.Info("sql.query_success", zap.Int("rows", len(out)))
+	return out, nil
+}
+
+func parseStatus(s string) domain.QuoteUpdateStatus {
+	switch s {
+	case "queued":
+		return domain.QuoteUpdateStatusQueued
+	case "processing":
+		return domain.QuoteUpdateStatusProcessing
+	case "done":
+		return domain.QuoteUpdateStatusDone
+	case "canceled":
+		return domain.QuoteUpdateStatusCanceled
+	default:
+		return domain.QuoteUpdateStatusFailed
+	}
+}
@@ -0,0 +1,73 @@
+package pg_test
+
+import (
+	"context"
+	"testing"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/pg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateJobRepo_ListAndCount_WithContainer(t *testing.T) {
+	db, done := withPostgres(t)
+	defer done()
+
+	repo := pg.NewUpdateJobRepo(db)
+	ctx := context.Background()
+
+	var ids []string
+	for _, p := range []string{"EUR/USD", "EUR/USD", "EUR/USD", "USD/MXN"} {
+		id, err := repo.CreateQueued(ctx, p, nil)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	require.NoError(t, repo.UpdateStatus(ctx, ids[0], domain.QuoteUpdateStatusDone, nil))
+
+	f := domain.QuoteUpdateFilter{Pair: "EUR/USD", Limit: 2}
+	first, err := repo.List(ctx, f)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+
+	last := first[len(first)-1]
+	f.After = &domain.QuoteUpdateCursor{RequestedAt: last.RequestedAt, ID: last.ID}
+	rest, err := repo.List(ctx, f)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	require.NotContains(t, []string{first[0].ID, first[1].ID}, rest[0].ID)
+
+	counts, err := repo.CountByStatus(ctx, domain.QuoteUpdateFilter{Pair: "EUR/USD", Status: domain.QuoteUpdateStatusDone})
+	require.NoError(t, err)
+	require.Equal(t, map[domain.QuoteUpdateStatus]int64{
+		domain.QuoteUpdateStatusQueued: 2,
+		domain.QuoteUpdateStatusDone:   1,
+	}, counts)
+}
+
+func TestUpdateJobRepo_CancelQueued_WithContainer(t *testing.T) {
+	db, done := withPostgres(t)
+	defer done()
+
+	repo := pg.NewUpdateJobRepo(db)
+	ctx := context.Background()
+
+	id, err := repo.CreateQueued(ctx, "EUR/USD", nil)
+	require.NoError(t, err)
+	ok, err := repo.CancelQueued(ctx, id)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	got, err := repo.GetByID(ctx, id)
+	require.NoError(t, err)
+	require.Equal(t, domain.QuoteUpdateStatusCanceled, got.Status)
+
+	ok, err = repo.ClaimByID(ctx, id)
+	require.NoError(t, err)
+	require.False(t, ok)
+	claimed, err := repo.ClaimQueued(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, claimed)
+
+	_, err = repo.CancelQueued(ctx, "00000000-0000-0000-0000-000000000000")
+	require.ErrorIs(t, err, domain.ErrNotFound)
+}
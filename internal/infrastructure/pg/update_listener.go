@@ -0,0 +1,80 @@
+package pg
+
+import (
+	"context"
+	"sync"
+)
+
+// UpdateStatusChannel is the NOTIFY channel carrying the ids of update jobs whose status changed.
+const UpdateStatusChannel = "quote_update_status"
+
+// UpdateListener holds one LISTEN connection and fans notifications out to in-process
+// subscribers, so that every API replica sees status changes made by any writer. The
+// connection is opened on the first Subscribe and kept until Close.
+type UpdateListener struct {
+	db     *DB
+	ctx    context.Context
+	cancel context.CancelFunc
+	start  sync.Once
+
+	mu   sync.Mutex
+	subs map[string]map[chan struct{}]struct{}
+}
+
+func NewUpdateListener(db *DB) *UpdateListener {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &UpdateListener{db: db, ctx: ctx, cancel: cancel, subs: map[string]map[chan struct{}]struct{}{}}
+}
+
+// Close stops listening.
+func (l *UpdateListener) Close() { l.cancel() }
+
+// Subscribe implements application.UpdateNotifier.
+func (l *UpdateListener) Subscribe(id string) (<-chan struct{}, func()) {
+	l.start.Do(func() { go l.run(l.ctx) })
+	ch := make(chan struct{}, 1)
+	l.mu.Lock()
+	if l.subs[id] == nil {
+		l.subs[id] = map[chan struct{}]struct{}{}
+	}
+	l.subs[id][ch] = struct{}{}
+	l.mu.Unlock()
+	return ch, func() {
+		l.mu.Lock()
+		delete(l.subs[id], ch)
+		if len(l.subs[id]) == 0 {
+			delete(l.subs, id)
+		}
+		l.mu.Unlock()
+	}
+}
+
+func (l *UpdateListener) run(ctx context.Context) {
+	// Notifications sent while disconnected are lost; let every waiter re-read its job.
+	listen(ctx, l.db, UpdateStatusChannel, l.wakeAll, l.wake)
+}
+
+func (l *UpdateListener) wake(id string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for ch := range l.subs[id] {
+		signal(ch)
+	}
+}
+
+func (l *UpdateListener) wakeAll() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, chans := range l.subs {
+		for ch := range chans {
+			signal(ch)
+		}
+	}
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,40 @@
+package pg_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/pg"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateListener_WithContainer(t *testing.T) {
+	db, done := withPostgres(t)
+	defer done()
+
+	repo := pg.NewUpdateJobRepo(db)
+	l := pg.NewUpdateListener(db)
+	defer l.Close()
+	ctx := context.Background()
+
+	id, err := repo.CreateQueued(ctx, "EUR/USD", nil)
+	require.NoError(t, err)
+	ch, release := l.Subscribe(id)
+	defer release()
+	// The first signal comes from the listener connecting.
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener did not connect")
+	}
+
+	require.NoError(t, repo.UpdateStatus(ctx, id, domain.QuoteUpdateStatusDone, nil))
+	select {
+	case <-ch:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notification after UpdateStatus")
+	}
+}
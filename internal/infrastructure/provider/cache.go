@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/logx"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// QuoteCache stores recently fetched quotes keyed by pair.
+type QuoteCache interface {
+	Get(ctx context.Context, pair string) (domain.Quote, bool, error)
+	Set(ctx context.Context, q domain.Quote, ttl time.Duration) error
+}
+
+// sharedFetchTimeout bounds an upstream call made on behalf of every caller waiting on it.
+const sharedFetchTimeout = 10 * time.Second
+
+// Ensure CachingProvider implements application.RateProvider.
+var _ application.RateProvider = (*CachingProvider)(nil)
+
+// CachingProvider decorates a RateProvider with a short-TTL cache.
+// Concurrent misses for the same pair within one process share a single upstream call,
+// which outlives any caller that gives up on it.
+type CachingProvider struct {
+	next  application.RateProvider
+	cache QuoteCache
+	ttl   time.Duration
+	group singleflight.Group
+}
+
+func NewCaching(next application.RateProvider, cache QuoteCache, ttl time.Duration) *CachingProvider {
+	return &CachingProvider{next: next, cache: cache, ttl: ttl}
+}
+
+func (p *CachingProvider) Get(ctx context.Context, pair string) (domain.Quote, error) {
+	log := logx.L().With(zap.String("provider", "cache"), zap.String("pair", pair))
+	q, ok, err := p.cache.Get(ctx, pair)
+	if err != nil {
+		// A broken cache must not take the provider down; fall through to upstream.
+		log.Warn("provider_cache.get_failed", zap.Error(err))
+	}
+	if ok {
+		q.Cached = true
+		return q, nil
+	}
+
+	leader := false
+	ch := p.group.DoChan(pair, func() (any, error) {
+		leader = true
+		// The fetch is shared, so no one caller's cancellation may end it; it keeps the
+		// leader's values and gets its own bound instead.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedFetchTimeout)
+		defer cancel()
+		q, err := p.next.Get(ctx, pair)
+		if err != nil {
+			return domain.Quote{}, err
+		}
+		if err := p.cache.Set(ctx, q, p.ttl); err != nil {
+			log.Warn("provider_cache.set_failed", zap.Error(err))
+		}
+		return q, nil
+	})
+	select {
+	case <-ctx.Done():
+		return domain.Quote{}, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return domain.Quote{}, res.Err
+		}
+		q = res.Val.(domain.Quote)
+	}
+	// Callers that joined an in-flight fetch did not reach upstream themselves.
+	q.Cached = !leader
+	return q, nil
+}
+
+// MemoryCache is an in-process QuoteCache.
+type MemoryCache struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	quote     domain.Quote
+	expiresAt time.Time
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{now: time.Now, entries: map[string]memoryEntry{}}
+}
+
+func (c *MemoryCache) Get(_ context.Context, pair string) (domain.Quote, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[pair]
+	if !ok {
+		return domain.Quote{}, false, nil
+	}
+	if !c.now().Before(e.expiresAt) {
+		delete(c.entries, pair)
+		return domain.Quote{}, false, nil
+	}
+	return e.quote, true, nil
+}
+
+func (c *MemoryCache) Set(_ context.Context, q domain.Quote, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[string(q.Pair)] = memoryEntry{quote: q, expiresAt: c.now().Add(ttl)}
+	return nil
+}
@@ -0,0 +1,150 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/provider"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingProvider struct {
+	calls atomic.Int32
+	gate  chan struct{}
+	err   error
+}
+
+func (p *countingProvider) Get(_ context.Context, pair string) (domain.Quote, error) {
+	p.calls.Add(1)
+	if p.gate != nil {
+		<-p.gate
+	}
+	if p.err != nil {
+		return domain.Quote{}, p.err
+	}
+	return domain.Quote{Pair: domain.Pair(pair), Price: 1.1, UpdatedAt: time.Unix(1731240000, 0).UTC()}, nil
+}
+
+func TestCachingProvider_HitWithinTTL(t *testing.T) {
+	up := &countingProvider{}
+	p := provider.NewCaching(up, provider.NewMemoryCache(), time.Minute)
+	ctx := context.Background()
+
+	first, err := p.Get(ctx, "EUR/USD")
+	require.NoError(t, err)
+	require.False(t, first.Cached)
+
+	second, err := p.Get(ctx, "EUR/USD")
+	require.NoError(t, err)
+	require.True(t, second.Cached)
+	require.InDelta(t, first.Price, second.Price, 1e-9)
+	require.Equal(t, int32(1), up.calls.Load())
+}
+
+func TestCachingProvider_ExpiresAfterTTL(t *testing.T) {
+	up := &countingProvider{}
+	p := provider.NewCaching(up, provider.NewMemoryCache(), 10*time.Millisecond)
+	ctx := context.Background()
+
+	_, err := p.Get(ctx, "EUR/USD")
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	q, err := p.Get(ctx, "EUR/USD")
+	require.NoError(t, err)
+	require.False(t, q.Cached)
+	require.Equal(t, int32(2), up.calls.Load())
+}
+
+func TestCachingProvider_ConcurrentMissesCollapse(t *testing.T) {
+	up := &countingProvider{gate: make(chan struct{})}
+	p := provider.NewCaching(up, provider.NewMemoryCache(), time.Minute)
+
+	const n = 10
+	var wg sync.WaitGroup
+	results := make([]domain.Quote, n)
+	errs := make([]error, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = p.Get(context.Background(), "EUR/USD")
+		}(i)
+	}
+	// Let every goroutine reach the in-flight call before releasing upstream.
+	time.Sleep(20 * time.Millisecond)
+	close(up.gate)
+	wg.Wait()
+
+	require.Equal(t, int32(1), up.calls.Load())
+	fresh := 0
+	for i, q := range results {
+		require.NoError(t, errs[i])
+		if !q.Cached {
+			fresh++
+		}
+	}
+	require.Equal(t, 1, fresh)
+}
+
+func TestCachingProvider_ErrorNotCached(t *testing.T) {
+	up := &countingProvider{err: errors.New("upstream down")}
+	p := provider.NewCaching(up, provider.NewMemoryCache(), time.Minute)
+	ctx := context.Background()
+
+	_, err := p.Get(ctx, "EUR/USD")
+	require.Error(t, err)
+	_, err = p.Get(ctx, "EUR/USD")
+	require.Error(t, err)
+	require.Equal(t, int32(2), up.calls.Load())
+}
+
+type ctxProvider struct {
+	calls atomic.Int32
+	gate  chan struct{}
+}
+
+func (p *ctxProvider) Get(ctx context.Context, pair string) (domain.Quote, error) {
+	p.calls.Add(1)
+	select {
+	case <-p.gate:
+		return domain.Quote{Pair: domain.Pair(pair), Price: 1.1}, nil
+	case <-ctx.Done():
+		return domain.Quote{}, ctx.Err()
+	}
+}
+
+func TestCachingProvider_LeaderCancelDoesNotFailFollowers(t *testing.T) {
+	up := &ctxProvider{gate: make(chan struct{})}
+	p := provider.NewCaching(up, provider.NewMemoryCache(), time.Minute)
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := p.Get(leaderCtx, "EUR/USD")
+		leaderErr <- err
+	}()
+	require.Eventually(t, func() bool { return up.calls.Load() == 1 }, time.Second, time.Millisecond)
+
+	follower := make(chan error, 1)
+	go func() {
+		_, err := p.Get(context.Background(), "EUR/USD")
+		follower <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	cancel()
+	require.ErrorIs(t, <-leaderErr, context.Canceled, "the leader stops waiting at once")
+	close(up.gate)
+	require.NoError(t, <-follower)
+	require.Equal(t, int32(1), up.calls.Load())
+
+	q, err := p.Get(context.Background(), "EUR/USD")
+	require.NoError(t, err)
+	require.True(t, q.Cached, "the shared fetch still filled the cache")
+}
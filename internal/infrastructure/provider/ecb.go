@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/httpx"
+)
+
+const (
+	ecbDailyPath = "/stats/eurofxref/eurofxref-daily.xml"
+	ecbBase      = "EUR"
+	ecbDateFmt   = "2006-01-02"
+)
+
+// ECBProvider reads the European Central Bank daily euro reference rates.
+// The feed is public and needs no API key; rates are published once per working day.
+type ECBProvider struct {
+	BaseURL string
+	Client  *httpx.Client
+	// Optional retry policy; if nil, httpx defaults apply. Prefer wiring from config.
+	Retry *httpx.RetryPolicy
+}
+
+var _ application.RateProvider = (*ECBProvider)(nil)
+
+// ecbEnvelope mirrors the gesmes envelope: Cube > Cube[time] > Cube[currency, rate].
+type ecbEnvelope struct {
+	XMLName xml.Name `xml:"Envelope"`
+	Cube    struct {
+		Days []struct {
+			Time  string `xml:"time,attr"`
+			Rates []struct {
+				Currency string  `xml:"currency,attr"`
+				Rate     float64 `xml:"rate,attr"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	} `xml:"Cube"`
+}
+
+func (p *ECBProvider) Get(ctx context.Context, pair string) (domain.Quote, error) {
+	if !domain.ValidatePair(pair) {
+		return domain.Quote{}, fmt.Errorf("provider: invalid pair %q", pair)
+	}
+	base := pair[:3]
+	quote := pair[4:]
+
+	u, err := url.Parse(p.BaseURL)
+	if err != nil {
+		return domain.Quote{}, fmt.Errorf("provider: base url: %w", err)
+	}
+	u.Path = ecbDailyPath
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+
+	var env ecbEnvelope
+	if err := p.Client.DoXML(ctx, req, &env, p.Retry); err != nil {
+		return domain.Quote{}, fmt.Errorf("provider: %w", err)
+	}
+	if len(env.Cube.Days) == 0 {
+		return domain.Quote{}, fmt.Errorf("provider: ecb feed has no rates")
+	}
+	// The daily feed carries a single day; the historical feeds list the newest first.
+	day := env.Cube.Days[0]
+	published, err := time.Parse(ecbDateFmt, day.Time)
+	if err != nil {
+		return domain.Quote{}, fmt.Errorf("provider: ecb publication date %q: %w", day.Time, err)
+	}
+	rates := make(map[string]float64, len(day.Rates))
+	for _, r := range day.Rates {
+		rates[r.Currency] = r.Rate
+	}
+	rate, err := crossRate(rates, ecbBase, base, quote)
+	if err != nil {
+		return domain.Quote{}, err
+	}
+	return domain.Quote{
+		Pair:      domain.Pair(pair),
+		Price:     rate,
+		UpdatedAt: published.UTC(),
+	}, nil
+}
@@ -0,0 +1,79 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/infrastructure/httpx"
+	"fxrates-service/internal/infrastructure/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func ecbServer(t *testing.T, fixture string) *httptest.Server {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", "ecb", fixture))
+	require.NoError(t, err)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stats/eurofxref/eurofxref-daily.xml" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/xml")
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func newECB(baseURL string) *provider.ECBProvider {
+	return &provider.ECBProvider{
+		BaseURL: baseURL,
+		Client:  &httpx.Client{HTTP: &http.Client{Timeout: 2 * time.Second}},
+	}
+}
+
+func TestECBProvider_Rates(t *testing.T) {
+	srv := ecbServer(t, "eurofxref-daily.xml")
+	p := newECB(srv.URL)
+	published := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		pair string
+		want float64
+	}{
+		{"EUR/USD", 1.0304},
+		{"USD/EUR", 1 / 1.0304},
+		{"USD/MXN", 21.2343 / 1.0304},
+		{"MXN/USD", 1.0304 / 21.2343},
+	}
+	for _, c := range cases {
+		q, err := p.Get(context.Background(), c.pair)
+		require.NoError(t, err, c.pair)
+		require.InDelta(t, c.want, q.Price, 1e-9, c.pair)
+		require.Equal(t, published, q.UpdatedAt, c.pair)
+	}
+}
+
+func TestECBProvider_MissingCurrency(t *testing.T) {
+	srv := ecbServer(t, "eurofxref-missing-mxn.xml")
+	p := newECB(srv.URL)
+
+	_, err := p.Get(context.Background(), "EUR/MXN")
+	require.ErrorContains(t, err, "missing rate for MXN")
+}
+
+func TestECBProvider_MalformedFeed(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("<Envelope><Cube>"))
+	}))
+	defer srv.Close()
+	p := newECB(srv.URL)
+
+	_, err := p.Get(context.Background(), "EUR/USD")
+	require.ErrorContains(t, err, "decode")
+}
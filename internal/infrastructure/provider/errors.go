@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error classes shared by provider adapters. Wrap them via APIError so callers can use errors.Is.
+var (
+	ErrUnauthorized   = errors.New("provider: unauthorized")
+	ErrForbidden      = errors.New("provider: not allowed on current plan")
+	ErrQuotaExceeded  = errors.New("provider: quota exceeded")
+	ErrNotFound       = errors.New("provider: not found")
+	ErrInvalidRequest = errors.New("provider: invalid request")
+	ErrUnavailable    = errors.New("provider: unavailable")
+)
+
+// APIError is an error payload returned by an upstream provider.
+type APIError struct {
+	Provider string
+	Status   int
+	Code     string
+	Message  string
+	// Kind is one of the Err* classes above.
+	Kind error
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("provider: %s api_error status=%d code=%s info=%s", e.Provider, e.Status, e.Code, e.Message)
+}
+
+func (e *APIError) Unwrap() error { return e.Kind }
+
+// kindForStatus is the fallback classification when a payload code is unknown.
+func kindForStatus(status int) error {
+	switch {
+	case status == 401:
+		return ErrUnauthorized
+	case status == 403:
+		return ErrForbidden
+	case status == 404:
+		return ErrNotFound
+	case status == 429:
+		return ErrQuotaExceeded
+	case status >= 500:
+		return ErrUnavailable
+	default:
+		return ErrInvalidRequest
+	}
+}
@@ -20,8 +20,8 @@ type ExchangeRatesAPIProvider struct {
 	BaseURL string
 	APIKey  string
 	Client  *httpx.Client
-	// Optional backoff config; if nil, httpx defaults apply. Prefer wiring from config.
-	BackoffCfg *httpx.BackoffConfig
+	// Optional retry policy; if nil, httpx defaults apply. Prefer wiring from config.
+	Retry *httpx.RetryPolicy
 }
 
 var _ application.RateProvider = (*ExchangeRatesAPIProvider)(nil)
@@ -55,7 +55,7 @@ func (p *ExchangeRatesAPIProvider) Get(ctx context.Context, pair string) (domain
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
 
 	var res apiResponse
-	if err := p.Client.DoJSON(ctx, req, &res, p.BackoffCfg); err != nil {
+	if err := p.Client.DoJSON(ctx, req, &res, p.Retry); err != nil {
 		return domain.Quote{}, fmt.Errorf("provider: %w", err)
 	}
 
@@ -69,30 +69,11 @@ func (p *ExchangeRatesAPIProvider) Get(ctx context.Context, pair string) (domain
 	rate, ok := res.Rates[pair]
 	if !ok {
 		// Compute cross-rate using provider base (typically EUR on free tier).
-		switch {
-		case res.Base == base:
-			// Pair base equals provider base: direct quote
-			r, ok := res.Rates[quote]
-			if !ok {
-				return domain.Quote{}, fmt.Errorf("provider: missing rate for %s", quote)
-			}
-			rate = r
-		case res.Base == quote:
-			// Pair quote equals provider base: invert base
-			bv, ok := res.Rates[base]
-			if !ok || bv == 0 {
-				return domain.Quote{}, fmt.Errorf("provider: missing or zero rate for %s", base)
-			}
-			rate = 1 / bv
-		default:
-			// Cross: QUOTE_per_BASE = (QUOTE_per_RESBASE) / (BASE_per_RESBASE)
-			qv, ok1 := res.Rates[quote]
-			bv, ok2 := res.Rates[base]
-			if !ok1 || !ok2 || bv == 0 {
-				return domain.Quote{}, fmt.Errorf("provider: missing rates for %s or %s", base, quote)
-			}
-			rate = qv / bv
+		r, err := crossRate(res.Rates, res.Base, base, quote)
+		if err != nil {
+			return domain.Quote{}, err
 		}
+		rate = r
 	}
 	return domain.Quote{
 		Pair:      domain.Pair(pair),
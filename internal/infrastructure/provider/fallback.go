@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/logx"
+
+	"go.uber.org/zap"
+)
+
+// Ensure Fallback implements application.RateProvider.
+var _ application.RateProvider = (*Fallback)(nil)
+
+// Fallback tries providers in order of their current health score, moving on to the next
+// one when a call fails. Ties keep the configured order.
+type Fallback struct {
+	providers []*Instrumented
+	health    application.ProviderHealthStore
+}
+
+func NewFallback(health application.ProviderHealthStore, providers ...*Instrumented) *Fallback {
+	return &Fallback{providers: providers, health: health}
+}
+
+func (f *Fallback) Get(ctx context.Context, pair string) (domain.Quote, error) {
+	var errs []error
+	for _, p := range f.ordered(ctx) {
+		q, err := p.Get(ctx, pair)
+		if err == nil {
+			return q, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+		if ctx.Err() != nil {
+			break
+		}
+		logx.L().Warn("provider_fallback.next", zap.String("provider", p.Name()), zap.String("pair", pair), zap.Error(err))
+	}
+	return domain.Quote{}, errors.Join(errs...)
+}
+
+func (f *Fallback) ordered(ctx context.Context) []*Instrumented {
+	out := append([]*Instrumented(nil), f.providers...)
+	stats, err := f.health.List(ctx)
+	if err != nil {
+		logx.L().Warn("provider_fallback.health_failed", zap.Error(err))
+		return out
+	}
+	score := make(map[string]float64, len(stats))
+	for _, h := range stats {
+		score[h.Provider] = h.Score()
+	}
+	scoreOf := func(name string) float64 {
+		if s, ok := score[name]; ok {
+			return s
+		}
+		return domain.ProviderHealth{}.Score()
+	}
+	sort.SliceStable(out, func(i, j int) bool { return scoreOf(out[i].Name()) > scoreOf(out[j].Name()) })
+	return out
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/httpx"
+	"fxrates-service/internal/infrastructure/logx"
+
+	"go.uber.org/zap"
+)
+
+// Error classes recorded for failed provider calls. "request" marks failures caused by
+// what we sent (bad pair, bad parameters); the rest point at the provider or the network.
+const (
+	ErrorClassTimeout     = "timeout"
+	ErrorClassNetwork     = "network"
+	ErrorClassAuth        = "auth"
+	ErrorClassQuota       = "quota"
+	ErrorClassRequest     = "request"
+	ErrorClassUnavailable = "unavailable"
+	ErrorClassOther       = "other"
+)
+
+// ClassifyError maps a provider call error to one of the ErrorClass* values.
+func ClassifyError(err error) string {
+	var serr *httpx.StatusError
+	var nerr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	case errors.Is(err, ErrUnauthorized), errors.Is(err, ErrForbidden):
+		return ErrorClassAuth
+	case errors.Is(err, ErrQuotaExceeded):
+		return ErrorClassQuota
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrInvalidRequest):
+		return ErrorClassRequest
+	case errors.Is(err, ErrUnavailable), errors.Is(err, ErrSimInjected):
+		return ErrorClassUnavailable
+	case errors.As(err, &serr):
+		switch {
+		case serr.StatusCode == 429:
+			return ErrorClassQuota
+		case serr.StatusCode == 401 || serr.StatusCode == 403:
+			return ErrorClassAuth
+		case serr.StatusCode >= 500:
+			return ErrorClassUnavailable
+		default:
+			return ErrorClassRequest
+		}
+	case errors.As(err, &nerr):
+		if nerr.Timeout() {
+			return ErrorClassTimeout
+		}
+		return ErrorClassNetwork
+	default:
+		return ErrorClassOther
+	}
+}
+
+// Ensure Instrumented implements application.RateProvider.
+var _ application.RateProvider = (*Instrumented)(nil)
+
+// Instrumented records latency and outcome of every call to the wrapped provider.
+// Calls canceled by our side are not recorded: they say nothing about the provider.
+type Instrumented struct {
+	name  string
+	next  application.RateProvider
+	store application.ProviderHealthStore
+	now   func() time.Time
+}
+
+func NewInstrumented(name string, next application.RateProvider, store application.ProviderHealthStore) *Instrumented {
+	return &Instrumented{name: name, next: next, store: store, now: time.Now}
+}
+
+// Name is the provider name used as the health key.
+func (p *Instrumented) Name() string { return p.name }
+
+func (p *Instrumented) Get(ctx context.Context, pair string) (domain.Quote, error) {
+	start := p.now()
+	q, err := p.next.Get(ctx, pair)
+	if errors.Is(err, context.Canceled) {
+		return q, err
+	}
+	call := domain.ProviderCall{Latency: p.now().Sub(start), At: start}
+	if err != nil {
+		call.ErrorClass = ClassifyError(err)
+	}
+	// Use a fresh context so a timed-out call is still recorded.
+	rctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+	defer cancel()
+	if rerr := p.store.Record(rctx, p.name, call); rerr != nil {
+		logx.L().Warn("provider_health.record_failed", zap.String("provider", p.name), zap.Error(rerr))
+	}
+	return q, err
+}
+
+// Ensure MemoryHealth implements application.ProviderHealthStore.
+var _ application.ProviderHealthStore = (*MemoryHealth)(nil)
+
+// MemoryHealth is an in-process ProviderHealthStore.
+type MemoryHealth struct {
+	mu    sync.Mutex
+	stats map[string]*domain.ProviderHealth
+}
+
+func NewMemoryHealth() *MemoryHealth {
+	return &MemoryHealth{stats: map[string]*domain.ProviderHealth{}}
+}
+
+func (m *MemoryHealth) Record(_ context.Context, provider string, call domain.ProviderCall) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	h, ok := m.stats[provider]
+	if !ok {
+		h = &domain.ProviderHealth{Provider: provider}
+		m.stats[provider] = h
+	}
+	h.Observe(call)
+	return nil
+}
+
+func (m *MemoryHealth) List(context.Context) ([]domain.ProviderHealth, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]domain.ProviderHealth, 0, len(m.stats))
+	for _, h := range m.stats {
+		out = append(out, *h)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Provider < out[j].Provider })
+	return out, nil
+}
+
+// Ensure WriteThroughHealth implements application.ProviderHealthStore.
+var _ application.ProviderHealthStore = (*WriteThroughHealth)(nil)
+
+// WriteThroughHealth keeps provider statistics in process and copies every call to a
+// shared store. Local is what this process ranks providers by, without a round trip per
+// request; List reads Shared so status reports include calls made by other processes.
+type WriteThroughHealth struct {
+	Local  *MemoryHealth
+	Shared application.ProviderHealthStore
+}
+
+func NewWriteThroughHealth(shared application.ProviderHealthStore) *WriteThroughHealth {
+	return &WriteThroughHealth{Local: NewMemoryHealth(), Shared: shared}
+}
+
+func (w *WriteThroughHealth) Record(ctx context.Context, provider string, call domain.ProviderCall) error {
+	_ = w.Local.Record(ctx, provider, call)
+	return w.Shared.Record(ctx, provider, call)
+}
+
+func (w *WriteThroughHealth) List(ctx context.Context) ([]domain.ProviderHealth, error) {
+	return w.Shared.List(ctx)
+}
@@ -0,0 +1,137 @@
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/httpx"
+	"fxrates-service/internal/infrastructure/provider"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stubProvider struct {
+	price float64
+	err   error
+	calls int
+}
+
+func (s *stubProvider) Get(_ context.Context, pair string) (domain.Quote, error) {
+	s.calls++
+	if s.err != nil {
+		return domain.Quote{}, s.err
+	}
+	return domain.Quote{Pair: domain.Pair(pair), Price: s.price}, nil
+}
+
+func TestClassifyError(t *testing.T) {
+	cases := map[error]string{
+		context.DeadlineExceeded:                           provider.ErrorClassTimeout,
+		&provider.APIError{Kind: provider.ErrUnauthorized}: provider.ErrorClassAuth,
+		fmt.Errorf("wrap: %w", provider.ErrQuotaExceeded):  provider.ErrorClassQuota,
+		provider.ErrInvalidRequest:                         provider.ErrorClassRequest,
+		&httpx.StatusError{StatusCode: 503}:                provider.ErrorClassUnavailable,
+		&httpx.StatusError{StatusCode: 429}:                provider.ErrorClassQuota,
+		&httpx.StatusError{StatusCode: 400}:                provider.ErrorClassRequest,
+		errors.New("boom"):                                 provider.ErrorClassOther,
+	}
+	for err, want := range cases {
+		require.Equal(t, want, provider.ClassifyError(err), err.Error())
+	}
+}
+
+func TestInstrumented_RecordsOutcomes(t *testing.T) {
+	store := provider.NewMemoryHealth()
+	up := &stubProvider{price: 1.1}
+	p := provider.NewInstrumented("ecb", up, store)
+	ctx := context.Background()
+
+	_, err := p.Get(ctx, "EUR/USD")
+	require.NoError(t, err)
+	up.err = &httpx.StatusError{StatusCode: 502}
+	_, err = p.Get(ctx, "EUR/USD")
+	require.Error(t, err)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	up.err = context.Canceled
+	_, _ = p.Get(canceled, "EUR/USD")
+
+	stats, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	h := stats[0]
+	require.Equal(t, "ecb", h.Provider)
+	require.EqualValues(t, 2, h.Calls)
+	require.EqualValues(t, 1, h.Failures)
+	require.Equal(t, provider.ErrorClassUnavailable, h.LastError)
+	require.NotNil(t, h.LastSuccess)
+	require.InDelta(t, 0.8, h.SuccessRate, 1e-9)
+}
+
+func TestFallback_PrefersHealthyProvider(t *testing.T) {
+	store := provider.NewMemoryHealth()
+	bad := &stubProvider{err: provider.ErrUnavailable}
+	good := &stubProvider{price: 1.2}
+	fb := provider.NewFallback(store,
+		provider.NewInstrumented("bad", bad, store),
+		provider.NewInstrumented("good", good, store),
+	)
+	ctx := context.Background()
+
+	q, err := fb.Get(ctx, "EUR/USD")
+	require.NoError(t, err)
+	require.Equal(t, 1.2, q.Price)
+	require.Equal(t, 1, bad.calls)
+
+	// "bad" now scores 0, so it is tried last.
+	_, err = fb.Get(ctx, "EUR/USD")
+	require.NoError(t, err)
+	require.Equal(t, 1, bad.calls)
+	require.Equal(t, 2, good.calls)
+
+	good.err = errors.New("down too")
+	_, err = fb.Get(ctx, "EUR/USD")
+	require.ErrorIs(t, err, provider.ErrUnavailable)
+	require.ErrorContains(t, err, "good: down too")
+}
+
+// countingHealth counts List calls on the shared store.
+type countingHealth struct {
+	*provider.MemoryHealth
+	lists int
+}
+
+func (c *countingHealth) List(ctx context.Context) ([]domain.ProviderHealth, error) {
+	c.lists++
+	return c.MemoryHealth.List(ctx)
+}
+
+func TestWriteThroughHealth_RanksLocallyAndShares(t *testing.T) {
+	shared := &countingHealth{MemoryHealth: provider.NewMemoryHealth()}
+	store := provider.NewWriteThroughHealth(shared)
+	bad := &stubProvider{err: provider.ErrUnavailable}
+	good := &stubProvider{price: 1.2}
+	fb := provider.NewFallback(store.Local,
+		provider.NewInstrumented("bad", bad, store),
+		provider.NewInstrumented("good", good, store),
+	)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := fb.Get(ctx, "EUR/USD")
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, bad.calls, "the local view already ranks bad last")
+	require.Zero(t, shared.lists, "ranking must not read the shared store")
+
+	// Another process reads the same calls from the shared store.
+	stats, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	require.Equal(t, int64(2), stats[1].Calls)
+	require.Equal(t, 1, shared.lists)
+}
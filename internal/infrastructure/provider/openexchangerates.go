@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/httpx"
+)
+
+const (
+	oxrLatestPath     = "/latest.json"
+	oxrHistoricalPath = "/historical/%s.json"
+	oxrDateFmt        = "2006-01-02"
+)
+
+// OpenExchangeRatesProvider reads USD-based rates from openexchangerates.org.
+type OpenExchangeRatesProvider struct {
+	BaseURL string
+	AppID   string
+	Client  *httpx.Client
+	// Optional retry policy; if nil, httpx defaults apply. Prefer wiring from config.
+	Retry *httpx.RetryPolicy
+}
+
+var _ application.RateProvider = (*OpenExchangeRatesProvider)(nil)
+
+type oxrResponse struct {
+	Timestamp int64              `json:"timestamp"`
+	Base      string             `json:"base"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+type oxrError struct {
+	Error       bool   `json:"error"`
+	Status      int    `json:"status"`
+	Message     string `json:"message"`
+	Description string `json:"description"`
+}
+
+// Get returns the latest quote for pair.
+func (p *OpenExchangeRatesProvider) Get(ctx context.Context, pair string) (domain.Quote, error) {
+	return p.fetch(ctx, pair, oxrLatestPath)
+}
+
+// GetAt returns the end-of-day quote for pair on the given date (UTC).
+func (p *OpenExchangeRatesProvider) GetAt(ctx context.Context, pair string, date time.Time) (domain.Quote, error) {
+	return p.fetch(ctx, pair, fmt.Sprintf(oxrHistoricalPath, date.UTC().Format(oxrDateFmt)))
+}
+
+func (p *OpenExchangeRatesProvider) fetch(ctx context.Context, pair, path string) (domain.Quote, error) {
+	if !domain.ValidatePair(pair) {
+		return domain.Quote{}, fmt.Errorf("provider: invalid pair %q", pair)
+	}
+	base := pair[:3]
+	quote := pair[4:]
+
+	u, err := url.Parse(p.BaseURL)
+	if err != nil {
+		return domain.Quote{}, fmt.Errorf("provider: base url: %w", err)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	q := u.Query()
+	q.Set("app_id", p.AppID)
+	// Changing base is a paid feature; request both symbols and compute the cross-rate.
+	q.Set("symbols", base+","+quote)
+	u.RawQuery = q.Encode()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+
+	var res oxrResponse
+	if err := p.Client.DoJSON(ctx, req, &res, p.Retry); err != nil {
+		return domain.Quote{}, oxrMapError(err)
+	}
+	rate, err := crossRate(res.Rates, res.Base, base, quote)
+	if err != nil {
+		return domain.Quote{}, err
+	}
+	return domain.Quote{
+		Pair:      domain.Pair(pair),
+		Price:     rate,
+		UpdatedAt: time.Unix(res.Timestamp, 0).UTC(),
+	}, nil
+}
+
+// oxrMapError turns an Open Exchange Rates error payload into a typed APIError.
+func oxrMapError(err error) error {
+	var serr *httpx.StatusError
+	if !errors.As(err, &serr) {
+		return fmt.Errorf("provider: %w", err)
+	}
+	var payload oxrError
+	if jerr := json.Unmarshal([]byte(serr.Body), &payload); jerr != nil || !payload.Error {
+		return &APIError{
+			Provider: "openexchangerates",
+			Status:   serr.StatusCode,
+			Message:  serr.Body,
+			Kind:     kindForStatus(serr.StatusCode),
+		}
+	}
+	kind := kindForStatus(serr.StatusCode)
+	switch payload.Message {
+	case "missing_app_id", "invalid_app_id":
+		kind = ErrUnauthorized
+	case "not_allowed":
+		kind = ErrQuotaExceeded
+	case "access_restricted":
+		kind = ErrForbidden
+	case "not_found":
+		kind = ErrNotFound
+	case "invalid_base":
+		kind = ErrInvalidRequest
+	}
+	return &APIError{
+		Provider: "openexchangerates",
+		Status:   serr.StatusCode,
+		Code:     payload.Message,
+		Message:  payload.Description,
+		Kind:     kind,
+	}
+}
@@ -0,0 +1,99 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/infrastructure/httpx"
+	"fxrates-service/internal/infrastructure/provider"
+	"github.com/stretchr/testify/require"
+)
+
+const oxrLatest = `{
+  "disclaimer": "Usage subject to terms: https://openexchangerates.org/terms",
+  "license": "https://openexchangerates.org/license",
+  "timestamp": 1731240000,
+  "base": "USD",
+  "rates": {"EUR": 0.9337, "MXN": 20.1845}
+}`
+
+const oxrHistorical = `{
+  "timestamp": 1704153599,
+  "base": "USD",
+  "rates": {"EUR": 0.9057, "MXN": 17.0211}
+}`
+
+func newOXR(baseURL string) *provider.OpenExchangeRatesProvider {
+	return &provider.OpenExchangeRatesProvider{
+		BaseURL: baseURL,
+		AppID:   "test-app",
+		Client:  &httpx.Client{HTTP: &http.Client{Timeout: 2 * time.Second}},
+		Retry:   &httpx.RetryPolicy{Initial: time.Millisecond, Max: time.Millisecond, MaxAttempts: 2},
+	}
+}
+
+func TestOpenExchangeRates_Latest(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/latest.json", r.URL.Path)
+		gotQuery = r.URL.RawQuery
+		_, _ = w.Write([]byte(oxrLatest))
+	}))
+	defer srv.Close()
+	p := newOXR(srv.URL + "/api")
+
+	q, err := p.Get(context.Background(), "USD/MXN")
+	require.NoError(t, err)
+	require.InDelta(t, 20.1845, q.Price, 1e-9)
+	require.Equal(t, time.Unix(1731240000, 0).UTC(), q.UpdatedAt)
+	require.Contains(t, gotQuery, "app_id=test-app")
+
+	q, err = p.Get(context.Background(), "EUR/MXN")
+	require.NoError(t, err)
+	require.InDelta(t, 20.1845/0.9337, q.Price, 1e-9)
+}
+
+func TestOpenExchangeRates_Historical(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/historical/2024-01-01.json", r.URL.Path)
+		_, _ = w.Write([]byte(oxrHistorical))
+	}))
+	defer srv.Close()
+	p := newOXR(srv.URL)
+
+	q, err := p.GetAt(context.Background(), "EUR/USD", time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.InDelta(t, 1/0.9057, q.Price, 1e-9)
+	require.Equal(t, time.Unix(1704153599, 0).UTC(), q.UpdatedAt)
+}
+
+func TestOpenExchangeRates_ErrorPayloads(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		want   error
+	}{
+		{401, `{"error": true, "status": 401, "message": "invalid_app_id", "description": "Invalid App ID provided."}`, provider.ErrUnauthorized},
+		{429, `{"error": true, "status": 429, "message": "not_allowed", "description": "Access denied."}`, provider.ErrQuotaExceeded},
+		{403, `{"error": true, "status": 403, "message": "access_restricted", "description": "Access restricted."}`, provider.ErrForbidden},
+		{400, `{"error": true, "status": 400, "message": "invalid_base", "description": "Invalid base."}`, provider.ErrInvalidRequest},
+		{404, `not json`, provider.ErrNotFound},
+	}
+	for _, c := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(c.status)
+			_, _ = w.Write([]byte(c.body))
+		}))
+		p := newOXR(srv.URL)
+		_, err := p.Get(context.Background(), "EUR/USD")
+		srv.Close()
+
+		require.ErrorIs(t, err, c.want, c.body)
+		var apiErr *provider.APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, c.status, apiErr.Status)
+	}
+}
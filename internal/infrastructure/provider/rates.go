@@ -0,0 +1,32 @@
+package provider
+
+import "fmt"
+
+// crossRate derives the BASE/QUOTE price from a rates table quoted against ratesBase.
+// The table maps currency code to units of that currency per one ratesBase.
+func crossRate(rates map[string]float64, ratesBase, base, quote string) (float64, error) {
+	switch {
+	case ratesBase == base:
+		// Pair base equals provider base: direct quote
+		r, ok := rates[quote]
+		if !ok {
+			return 0, fmt.Errorf("provider: missing rate for %s", quote)
+		}
+		return r, nil
+	case ratesBase == quote:
+		// Pair quote equals provider base: invert base
+		bv, ok := rates[base]
+		if !ok || bv == 0 {
+			return 0, fmt.Errorf("provider: missing or zero rate for %s", base)
+		}
+		return 1 / bv, nil
+	default:
+		// Cross: QUOTE_per_BASE = (QUOTE_per_RESBASE) / (BASE_per_RESBASE)
+		qv, ok1 := rates[quote]
+		bv, ok2 := rates[base]
+		if !ok1 || !ok2 || bv == 0 {
+			return 0, fmt.Errorf("provider: missing rates for %s or %s", base, quote)
+		}
+		return qv / bv, nil
+	}
+}
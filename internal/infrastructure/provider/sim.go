@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+)
+
+type SimMode string
+
+const (
+	// SimRandomWalk moves each pair by a log-normal step on every call.
+	SimRandomWalk SimMode = "random_walk"
+	// SimScript replays per-pair price series from a file, wrapping around at the end.
+	SimScript SimMode = "script"
+	// SimShock is a random walk with a step jump every ShockEvery calls.
+	SimShock SimMode = "shock"
+)
+
+// DefaultSimHang is how long a simulated timeout blocks when SimConfig.Hang is unset.
+const DefaultSimHang = 5 * time.Second
+
+// ErrSimInjected is returned for calls failed on purpose by ErrorRate.
+var ErrSimInjected = errors.New("provider: simulated failure")
+
+// SimConfig drives Sim. Zero values disable the corresponding behaviour.
+type SimConfig struct {
+	Mode       SimMode
+	Seed       int64
+	StartPrice float64
+	// Volatility is the per-call standard deviation of the log return.
+	Volatility float64
+	// ScriptFile holds "PAIR,PRICE" lines; blank lines and # comments are ignored.
+	ScriptFile string
+	ShockEvery int
+	// ShockPct is the relative size of a shock (0.05 = 5%); direction is random.
+	ShockPct float64
+
+	Latency time.Duration
+	// ErrorRate and TimeoutRate are probabilities in [0,1]. A timed-out call blocks for
+	// Hang, or until the caller's context is done, and fails with context.DeadlineExceeded.
+	ErrorRate   float64
+	TimeoutRate float64
+	// Hang defaults to DefaultSimHang. It is bounded so that callers without a deadline
+	// of their own, like the db worker, are not stalled for good.
+	Hang time.Duration
+
+	// Now stamps quotes; defaults to time.Now.
+	Now func() time.Time
+}
+
+// Ensure Sim implements application.RateProvider.
+var _ application.RateProvider = (*Sim)(nil)
+
+// Sim is a deterministic, scriptable RateProvider for local runs and tests.
+type Sim struct {
+	cfg    SimConfig
+	mu     sync.Mutex
+	faults *rand.Rand
+	series map[string]*simSeries
+	script map[string][]float64
+}
+
+type simSeries struct {
+	rng   *rand.Rand
+	price float64
+	step  int
+}
+
+func NewSim(cfg SimConfig) (*Sim, error) {
+	if cfg.Mode == "" {
+		cfg.Mode = SimRandomWalk
+	}
+	if cfg.StartPrice <= 0 {
+		cfg.StartPrice = 1
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	if cfg.Hang <= 0 {
+		cfg.Hang = DefaultSimHang
+	}
+	s := &Sim{
+		cfg:    cfg,
+		faults: rand.New(rand.NewSource(cfg.Seed)),
+		series: map[string]*simSeries{},
+	}
+	switch cfg.Mode {
+	case SimRandomWalk, SimShock:
+	case SimScript:
+		script, err := loadSimScript(cfg.ScriptFile)
+		if err != nil {
+			return nil, err
+		}
+		s.script = script
+	default:
+		return nil, fmt.Errorf("provider: unsupported sim mode %q", cfg.Mode)
+	}
+	return s, nil
+}
+
+func (s *Sim) Get(ctx context.Context, pair string) (domain.Quote, error) {
+	s.mu.Lock()
+	fail := s.cfg.ErrorRate > 0 && s.faults.Float64() < s.cfg.ErrorRate
+	hang := !fail && s.cfg.TimeoutRate > 0 && s.faults.Float64() < s.cfg.TimeoutRate
+	s.mu.Unlock()
+
+	if s.cfg.Latency > 0 {
+		t := time.NewTimer(s.cfg.Latency)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return domain.Quote{}, ctx.Err()
+		case <-t.C:
+		}
+	}
+	if hang {
+		t := time.NewTimer(s.cfg.Hang)
+		defer t.Stop()
+		select {
+		case <-ctx.Done():
+			return domain.Quote{}, ctx.Err()
+		case <-t.C:
+			return domain.Quote{}, context.DeadlineExceeded
+		}
+	}
+	if fail {
+		return domain.Quote{}, ErrSimInjected
+	}
+
+	price, err := s.next(pair)
+	if err != nil {
+		return domain.Quote{}, err
+	}
+	return domain.Quote{
+		Pair:      domain.Pair(pair),
+		Price:     price,
+		UpdatedAt: s.cfg.Now().UTC(),
+	}, nil
+}
+
+func (s *Sim) next(pair string) (float64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cfg.Mode == SimScript {
+		prices, ok := s.script[pair]
+		if !ok {
+			return 0, fmt.Errorf("provider: sim script has no prices for %s", pair)
+		}
+		ser := s.seriesFor(pair)
+		p := prices[ser.step%len(prices)]
+		ser.step++
+		return p, nil
+	}
+
+	ser := s.seriesFor(pair)
+	ser.step++
+	if s.cfg.Volatility > 0 {
+		ser.price *= math.Exp(s.cfg.Volatility * ser.rng.NormFloat64())
+	}
+	if s.cfg.Mode == SimShock && s.cfg.ShockEvery > 0 && ser.step%s.cfg.ShockEvery == 0 {
+		if ser.rng.Intn(2) == 0 {
+			ser.price *= 1 + s.cfg.ShockPct
+		} else {
+			ser.price /= 1 + s.cfg.ShockPct
+		}
+	}
+	return ser.price, nil
+}
+
+// seriesFor keeps an independent generator per pair so that each pair's path depends
+// only on the seed, not on how calls for different pairs interleave.
+func (s *Sim) seriesFor(pair string) *simSeries {
+	ser, ok := s.series[pair]
+	if !ok {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(pair))
+		ser = &simSeries{
+			rng:   rand.New(rand.NewSource(s.cfg.Seed ^ int64(h.Sum64()))),
+			price: s.cfg.StartPrice,
+		}
+		s.series[pair] = ser
+	}
+	return ser
+}
+
+func loadSimScript(path string) (map[string][]float64, error) {
+	if path == "" {
+		return nil, fmt.Errorf("provider: sim script file is required in %s mode", SimScript)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("provider: sim script: %w", err)
+	}
+	defer f.Close()
+
+	out := map[string][]float64{}
+	sc := bufio.NewScanner(f)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		pair, raw, ok := strings.Cut(text, ",")
+		if !ok {
+			return nil, fmt.Errorf("provider: sim script line %d: want PAIR,PRICE", line)
+		}
+		pair = strings.TrimSpace(pair)
+		price, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return nil, fmt.Errorf("provider: sim script line %d: %w", line, err)
+		}
+		out[pair] = append(out[pair], price)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("provider: sim script: %w", err)
+	}
+	return out, nil
+}
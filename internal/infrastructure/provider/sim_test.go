@@ -0,0 +1,97 @@
+package provider_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/infrastructure/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func simPrices(t *testing.T, s *provider.Sim, pair string, n int) []float64 {
+	t.Helper()
+	out := make([]float64, n)
+	for i := range out {
+		q, err := s.Get(context.Background(), pair)
+		require.NoError(t, err)
+		out[i] = q.Price
+	}
+	return out
+}
+
+func TestSim_RandomWalkDeterministicPerPair(t *testing.T) {
+	cfg := provider.SimConfig{Mode: provider.SimRandomWalk, Seed: 42, StartPrice: 1.1, Volatility: 0.01}
+	a, err := provider.NewSim(cfg)
+	require.NoError(t, err)
+	b, err := provider.NewSim(cfg)
+	require.NoError(t, err)
+
+	// Interleaving another pair on one instance must not change EUR/USD's path.
+	_ = simPrices(t, a, "USD/MXN", 3)
+	pa := simPrices(t, a, "EUR/USD", 5)
+	pb := simPrices(t, b, "EUR/USD", 5)
+	require.Equal(t, pa, pb)
+	require.NotEqual(t, pa[0], pa[1])
+}
+
+func TestSim_ScriptWrapsAround(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "series.csv")
+	require.NoError(t, os.WriteFile(path, []byte("# pair,price\nEUR/USD,1.10\nEUR/USD,1.20\n\nUSD/MXN,17.5\n"), 0o644))
+	s, err := provider.NewSim(provider.SimConfig{Mode: provider.SimScript, ScriptFile: path})
+	require.NoError(t, err)
+
+	require.Equal(t, []float64{1.10, 1.20, 1.10}, simPrices(t, s, "EUR/USD", 3))
+	require.Equal(t, []float64{17.5}, simPrices(t, s, "USD/MXN", 1))
+	_, err = s.Get(context.Background(), "EUR/MXN")
+	require.ErrorContains(t, err, "no prices for EUR/MXN")
+}
+
+func TestSim_ShockJumps(t *testing.T) {
+	s, err := provider.NewSim(provider.SimConfig{Mode: provider.SimShock, Seed: 7, StartPrice: 2, ShockEvery: 3, ShockPct: 0.5})
+	require.NoError(t, err)
+
+	p := simPrices(t, s, "EUR/USD", 3)
+	require.Equal(t, 2.0, p[0])
+	require.Equal(t, 2.0, p[1])
+	require.Contains(t, []float64{3.0, 2 / 1.5}, p[2])
+}
+
+func TestSim_InjectedFaults(t *testing.T) {
+	s, err := provider.NewSim(provider.SimConfig{ErrorRate: 1})
+	require.NoError(t, err)
+	_, err = s.Get(context.Background(), "EUR/USD")
+	require.ErrorIs(t, err, provider.ErrSimInjected)
+
+	s, err = provider.NewSim(provider.SimConfig{TimeoutRate: 1})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = s.Get(ctx, "EUR/USD")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Without a deadline of its own the caller still gets an answer.
+	s, err = provider.NewSim(provider.SimConfig{TimeoutRate: 1, Hang: 10 * time.Millisecond})
+	require.NoError(t, err)
+	_, err = s.Get(context.Background(), "EUR/USD")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSim_LatencyAndClock(t *testing.T) {
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	s, err := provider.NewSim(provider.SimConfig{Latency: 15 * time.Millisecond, Now: func() time.Time { return ts }})
+	require.NoError(t, err)
+
+	start := time.Now()
+	q, err := s.Get(context.Background(), "EUR/USD")
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, time.Since(start), 15*time.Millisecond)
+	require.Equal(t, ts, q.UpdatedAt)
+}
+
+func TestSim_UnknownMode(t *testing.T) {
+	_, err := provider.NewSim(provider.SimConfig{Mode: "bogus"})
+	require.Error(t, err)
+}
@@ -0,0 +1,112 @@
+// Package redact scrubs credentials from URLs, headers and free-form error text before
+// they are logged, persisted or returned to clients.
+package redact
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Placeholder replaces every scrubbed value.
+const Placeholder = "REDACTED"
+
+// SecretParams lists query parameters that carry provider credentials.
+var SecretParams = []string{"access_key", "app_id", "api_key", "apikey", "token"}
+
+// SecretHeaders lists request headers that carry credentials.
+var SecretHeaders = []string{"Authorization", "Proxy-Authorization", "X-Api-Key", "Cookie"}
+
+var (
+	paramRe  = regexp.MustCompile(`(?i)\b(` + strings.Join(SecretParams, "|") + `)=[^&\s"']*`)
+	bearerRe = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]+`)
+
+	mu      sync.RWMutex
+	secrets []string
+)
+
+// Register adds literal secret values (configured API keys, tokens) that String removes
+// wherever they appear. Empty and very short values are ignored to avoid mangling text.
+func Register(values ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, v := range values {
+		if len(v) < 4 || slices.Contains(secrets, v) {
+			continue
+		}
+		secrets = append(secrets, v)
+	}
+	// Longest first, so a secret containing another is replaced whole.
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+}
+
+// String scrubs secret query parameters, authorization schemes and registered secret
+// values from s.
+func String(s string) string {
+	if s == "" {
+		return s
+	}
+	s = paramRe.ReplaceAllString(s, "${1}="+Placeholder)
+	s = bearerRe.ReplaceAllString(s, "${1} "+Placeholder)
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, v := range secrets {
+		s = strings.ReplaceAll(s, v, Placeholder)
+	}
+	return s
+}
+
+// Error returns the scrubbed message of err, or "" for nil.
+func Error(err error) string {
+	if err == nil {
+		return ""
+	}
+	return String(err.Error())
+}
+
+// URL scrubs secret query parameters and user info from a URL string.
+func URL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return String(raw)
+	}
+	if u.User != nil {
+		u.User = url.User(Placeholder)
+	}
+	q := u.Query()
+	changed := false
+	for k := range q {
+		if isSecretParam(k) {
+			q.Set(k, Placeholder)
+			changed = true
+		}
+	}
+	if changed {
+		u.RawQuery = q.Encode()
+	}
+	return String(u.String())
+}
+
+// Header returns a copy of h with secret header values replaced.
+func Header(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range SecretHeaders {
+		if _, ok := out[http.CanonicalHeaderKey(k)]; ok {
+			out.Set(k, Placeholder)
+		}
+	}
+	return out
+}
+
+func isSecretParam(k string) bool {
+	for _, p := range SecretParams {
+		if strings.EqualFold(k, p) {
+			return true
+		}
+	}
+	return false
+}
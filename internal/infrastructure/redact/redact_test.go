@@ -0,0 +1,47 @@
+package redact_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"fxrates-service/internal/infrastructure/redact"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestString_ScrubsParamsAndAuth(t *testing.T) {
+	in := `Get "https://api.example.com/v1/latest?access_key=abc123&symbols=USD": dial tcp: refused; Authorization: Bearer tok.en-1`
+	out := redact.String(in)
+	require.NotContains(t, out, "abc123")
+	require.NotContains(t, out, "tok.en-1")
+	require.Contains(t, out, "access_key=REDACTED&symbols=USD")
+	require.Contains(t, out, "Bearer REDACTED")
+}
+
+func TestString_RegisteredSecrets(t *testing.T) {
+	redact.Register("s3cr3t-app-id", "")
+	require.Equal(t, "provider said REDACTED is invalid", redact.String("provider said s3cr3t-app-id is invalid"))
+}
+
+func TestError_URLError(t *testing.T) {
+	err := &url.Error{Op: "Get", URL: "https://x.test/latest.json?app_id=zzz999&base=USD", Err: errors.New("EOF")}
+	require.NotContains(t, redact.Error(err), "zzz999")
+	require.Equal(t, "", redact.Error(nil))
+}
+
+func TestURL(t *testing.T) {
+	out := redact.URL("https://user:pw@x.test/p?ApiKey=k1&pair=EUR")
+	require.NotContains(t, out, "k1")
+	require.NotContains(t, out, "pw")
+	require.Contains(t, out, "pair=EUR")
+}
+
+func TestHeader(t *testing.T) {
+	h := http.Header{"Authorization": {"Bearer abc"}, "Accept": {"application/json"}}
+	out := redact.Header(h)
+	require.Equal(t, redact.Placeholder, out.Get("Authorization"))
+	require.Equal(t, "application/json", out.Get("Accept"))
+	require.Equal(t, "Bearer abc", h.Get("Authorization"))
+}
@@ -1,8 +1,18 @@
 package redisstore
 
-import "context"
+import (
+	"context"
+
+	"fxrates-service/internal/domain"
+)
 
 // NoopIdempotency always succeeds; useful for tests/dev when Redis is disabled.
 type NoopIdempotency struct{}
 
-func (NoopIdempotency) TryReserve(context.Context, string) (bool, error) { return true, nil }
+func (NoopIdempotency) Reserve(context.Context, string, string) (domain.IdempotencyRecord, bool, error) {
+	return domain.IdempotencyRecord{}, true, nil
+}
+
+func (NoopIdempotency) Complete(context.Context, string, domain.IdempotencyRecord) error { return nil }
+
+func (NoopIdempotency) Release(context.Context, string) error { return nil }
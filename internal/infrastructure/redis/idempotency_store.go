@@ -2,8 +2,12 @@ package redisstore
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"time"
 
+	"fxrates-service/internal/domain"
+
 	"github.com/redis/go-redis/v9"
 )
 
@@ -16,10 +20,47 @@ func New(client *redis.Client, ttl time.Duration) *Store {
 	return &Store{Client: client, TTL: ttl}
 }
 
-func (s *Store) TryReserve(ctx context.Context, key string) (bool, error) {
-	ok, err := s.Client.SetNX(ctx, key, "1", s.TTL).Result()
+type storedIdempotency struct {
+	Fingerprint string `json:"fingerprint"`
+	UpdateID    string `json:"update_id,omitempty"`
+	BatchID     string `json:"batch_id,omitempty"`
+	Status      int    `json:"status,omitempty"`
+}
+
+func (s *Store) Reserve(ctx context.Context, key, fingerprint string) (domain.IdempotencyRecord, bool, error) {
+	val, err := json.Marshal(storedIdempotency{Fingerprint: fingerprint})
+	if err != nil {
+		return domain.IdempotencyRecord{}, false, err
+	}
+	// The key may expire between SETNX and GET; one more round settles it.
+	for i := 0; i < 2; i++ {
+		ok, err := s.Client.SetNX(ctx, key, val, s.TTL).Result()
+		if err != nil || ok {
+			return domain.IdempotencyRecord{}, ok, err
+		}
+		b, err := s.Client.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return domain.IdempotencyRecord{}, false, err
+		}
+		var st storedIdempotency
+		// Keys written before records were stored hold "1"; they read as in flight.
+		_ = json.Unmarshal(b, &st)
+		return domain.IdempotencyRecord{Fingerprint: st.Fingerprint, UpdateID: st.UpdateID, BatchID: st.BatchID, Status: st.Status}, false, nil
+	}
+	return domain.IdempotencyRecord{}, false, errors.New("idempotency key expired while reserving")
+}
+
+func (s *Store) Complete(ctx context.Context, key string, rec domain.IdempotencyRecord) error {
+	val, err := json.Marshal(storedIdempotency{Fingerprint: rec.Fingerprint, UpdateID: rec.UpdateID, BatchID: rec.BatchID, Status: rec.Status})
 	if err != nil {
-		return false, err
+		return err
 	}
-	return ok, nil
+	return s.Client.Set(ctx, key, val, s.TTL).Err()
+}
+
+func (s *Store) Release(ctx context.Context, key string) error {
+	return s.Client.Del(ctx, key).Err()
 }
@@ -5,13 +5,14 @@ import (
 	"testing"
 	"time"
 
+	"fxrates-service/internal/domain"
 	redisstore "fxrates-service/internal/infrastructure/redis"
 	miniredis "github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/require"
 )
 
-func TestTryReserve(t *testing.T) {
+func TestReserve(t *testing.T) {
 	mr, err := miniredis.Run()
 	require.NoError(t, err)
 	defer mr.Close()
@@ -20,11 +21,38 @@ func TestTryReserve(t *testing.T) {
 	store := redisstore.New(client, time.Hour)
 
 	ctx := context.Background()
-	ok, err := store.TryReserve(ctx, "k1")
+	_, ok, err := store.Reserve(ctx, "k1", "fp")
 	require.NoError(t, err)
 	require.True(t, ok)
 
-	ok, err = store.TryReserve(ctx, "k1")
+	rec, ok, err := store.Reserve(ctx, "k1", "fp")
 	require.NoError(t, err)
 	require.False(t, ok)
+	require.Equal(t, "fp", rec.Fingerprint)
+	require.False(t, rec.Completed())
+
+	require.NoError(t, store.Complete(ctx, "k1", domain.IdempotencyRecord{Fingerprint: "fp", UpdateID: "u1"}))
+	rec, ok, err = store.Reserve(ctx, "k1", "other")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, domain.IdempotencyRecord{Fingerprint: "fp", UpdateID: "u1"}, rec)
+	require.Greater(t, mr.TTL("k1"), time.Duration(0))
+
+	require.NoError(t, store.Release(ctx, "k1"))
+	_, ok, err = store.Reserve(ctx, "k1", "fp")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestReserve_LegacyValue(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+	require.NoError(t, mr.Set("k1", "1"))
+
+	store := redisstore.New(redis.NewClient(&redis.Options{Addr: mr.Addr()}), time.Hour)
+	rec, ok, err := store.Reserve(context.Background(), "k1", "fp")
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, domain.IdempotencyRecord{}, rec)
 }
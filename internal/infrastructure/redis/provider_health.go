@@ -0,0 +1,130 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	providerHealthPrefix = "provider_health:"
+	providerHealthIndex  = "provider_health_index"
+)
+
+// ProviderHealthStore shares provider statistics between the API and worker processes.
+// Updates use WATCH/MULTI so concurrent writers do not lose calls.
+type ProviderHealthStore struct {
+	Client *redis.Client
+}
+
+func NewProviderHealthStore(client *redis.Client) *ProviderHealthStore {
+	return &ProviderHealthStore{Client: client}
+}
+
+type storedHealth struct {
+	Calls       int64      `json:"calls"`
+	Failures    int64      `json:"failures"`
+	SuccessRate float64    `json:"success_rate"`
+	AvgLatency  int64      `json:"avg_latency_ns"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	LastFailure *time.Time `json:"last_failure,omitempty"`
+	LastError   string     `json:"last_error,omitempty"`
+}
+
+func (s *ProviderHealthStore) Record(ctx context.Context, provider string, call domain.ProviderCall) error {
+	key := providerHealthPrefix + provider
+	update := func(tx *redis.Tx) error {
+		h := domain.ProviderHealth{Provider: provider}
+		b, err := tx.Get(ctx, key).Bytes()
+		switch {
+		case errors.Is(err, redis.Nil):
+		case err != nil:
+			return err
+		default:
+			if h, err = decodeHealth(provider, b); err != nil {
+				return err
+			}
+		}
+		h.Observe(call)
+		out, err := json.Marshal(storedHealth{
+			Calls:       h.Calls,
+			Failures:    h.Failures,
+			SuccessRate: h.SuccessRate,
+			AvgLatency:  int64(h.AvgLatency),
+			LastSuccess: h.LastSuccess,
+			LastFailure: h.LastFailure,
+			LastError:   h.LastError,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(p redis.Pipeliner) error {
+			p.Set(ctx, key, out, 0)
+			p.SAdd(ctx, providerHealthIndex, provider)
+			return nil
+		})
+		return err
+	}
+	for i := 0; i < 5; i++ {
+		err := s.Client.Watch(ctx, update, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return redis.TxFailedErr
+}
+
+func (s *ProviderHealthStore) List(ctx context.Context) ([]domain.ProviderHealth, error) {
+	names, err := s.Client.SMembers(ctx, providerHealthIndex).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	sort.Strings(names)
+	keys := make([]string, len(names))
+	for i, n := range names {
+		keys[i] = providerHealthPrefix + n
+	}
+	vals, err := s.Client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]domain.ProviderHealth, 0, len(names))
+	for i, v := range vals {
+		raw, ok := v.(string)
+		if !ok {
+			continue
+		}
+		h, err := decodeHealth(names[i], []byte(raw))
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, h)
+	}
+	return out, nil
+}
+
+func decodeHealth(provider string, b []byte) (domain.ProviderHealth, error) {
+	var sh storedHealth
+	if err := json.Unmarshal(b, &sh); err != nil {
+		return domain.ProviderHealth{}, err
+	}
+	return domain.ProviderHealth{
+		Provider:    provider,
+		Calls:       sh.Calls,
+		Failures:    sh.Failures,
+		SuccessRate: sh.SuccessRate,
+		AvgLatency:  time.Duration(sh.AvgLatency),
+		LastSuccess: sh.LastSuccess,
+		LastFailure: sh.LastFailure,
+		LastError:   sh.LastError,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package redisstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderHealthStore_RecordList(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	store := redisstore.NewProviderHealthStore(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	ctx := context.Background()
+
+	stats, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Empty(t, stats)
+
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, store.Record(ctx, "oxr", domain.ProviderCall{Latency: 100 * time.Millisecond, At: ts}))
+	require.NoError(t, store.Record(ctx, "oxr", domain.ProviderCall{Latency: 200 * time.Millisecond, ErrorClass: "quota", At: ts.Add(time.Second)}))
+	require.NoError(t, store.Record(ctx, "ecb", domain.ProviderCall{Latency: 50 * time.Millisecond, At: ts}))
+
+	stats, err = store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, stats, 2)
+	require.Equal(t, "ecb", stats[0].Provider)
+	oxr := stats[1]
+	require.Equal(t, "oxr", oxr.Provider)
+	require.EqualValues(t, 2, oxr.Calls)
+	require.EqualValues(t, 1, oxr.Failures)
+	require.Equal(t, "quota", oxr.LastError)
+	require.Equal(t, 120*time.Millisecond, oxr.AvgLatency)
+	require.True(t, ts.Equal(*oxr.LastSuccess))
+	require.True(t, ts.Add(time.Second).Equal(*oxr.LastFailure))
+}
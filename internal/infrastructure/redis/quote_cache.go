@@ -0,0 +1,60 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const quoteCachePrefix = "quote_cache:"
+
+// QuoteCache keeps provider responses in Redis so that API and worker replicas share them.
+type QuoteCache struct {
+	Client *redis.Client
+}
+
+func NewQuoteCache(client *redis.Client) *QuoteCache {
+	return &QuoteCache{Client: client}
+}
+
+type cachedQuote struct {
+	Pair      string    `json:"pair"`
+	Price     float64   `json:"price"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (c *QuoteCache) Get(ctx context.Context, pair string) (domain.Quote, bool, error) {
+	b, err := c.Client.Get(ctx, quoteCachePrefix+pair).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return domain.Quote{}, false, nil
+	}
+	if err != nil {
+		return domain.Quote{}, false, err
+	}
+	var cq cachedQuote
+	if err := json.Unmarshal(b, &cq); err != nil {
+		return domain.Quote{}, false, err
+	}
+	return domain.Quote{
+		Pair:      domain.Pair(cq.Pair),
+		Price:     cq.Price,
+		UpdatedAt: cq.UpdatedAt,
+	}, true, nil
+}
+
+func (c *QuoteCache) Set(ctx context.Context, q domain.Quote, ttl time.Duration) error {
+	b, err := json.Marshal(cachedQuote{
+		Pair:      string(q.Pair),
+		Price:     q.Price,
+		UpdatedAt: q.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Client.Set(ctx, quoteCachePrefix+string(q.Pair), b, ttl).Err()
+}
@@ -0,0 +1,42 @@
+package redisstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/domain"
+	redisstore "fxrates-service/internal/infrastructure/redis"
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteCache_SetGetExpire(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := redisstore.NewQuoteCache(client)
+	ctx := context.Background()
+
+	_, ok, err := cache.Get(ctx, "EUR/USD")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, cache.Set(ctx, domain.Quote{Pair: "EUR/USD", Price: 1.08, UpdatedAt: ts}, time.Second))
+
+	q, ok, err := cache.Get(ctx, "EUR/USD")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, domain.Pair("EUR/USD"), q.Pair)
+	require.InDelta(t, 1.08, q.Price, 1e-9)
+	require.True(t, ts.Equal(q.UpdatedAt))
+
+	mr.FastForward(2 * time.Second)
+	_, ok, err = cache.Get(ctx, "EUR/USD")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
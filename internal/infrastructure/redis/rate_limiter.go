@@ -0,0 +1,66 @@
+package redisstore
+
+import (
+	"context"
+	"time"
+
+	"fxrates-service/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const rateLimitPrefix = "rate_limit:"
+
+// slidingWindow keeps one sorted-set member per allowed request, scored by its time in
+// milliseconds. Rejected requests are not recorded, so a client that keeps retrying
+// still gets through once the window moves on.
+//
+// KEYS[1] the window; ARGV now (ms), window (ms), limit, member.
+// Returns {allowed, requests in window, ms until the oldest one expires}.
+var slidingWindow = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+  redis.call('ZADD', KEYS[1], now, ARGV[4])
+  count = count + 1
+  allowed = 1
+end
+redis.call('PEXPIRE', KEYS[1], window)
+local reset = window
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+if oldest[2] then
+  reset = tonumber(oldest[2]) + window - now
+end
+return {allowed, count, reset}
+`)
+
+// RateLimiter is a sliding-window log in Redis, so every API replica draws on the same budget.
+type RateLimiter struct {
+	Client *redis.Client
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+func NewRateLimiter(client *redis.Client) *RateLimiter {
+	return &RateLimiter{Client: client, Now: time.Now}
+}
+
+func (l *RateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (domain.RateLimitDecision, error) {
+	now := l.Now().UnixMilli()
+	res, err := slidingWindow.Run(ctx, l.Client, []string{rateLimitPrefix + key},
+		now, window.Milliseconds(), limit, uuid.NewString()).Int64Slice()
+	if err != nil {
+		return domain.RateLimitDecision{}, err
+	}
+	return domain.RateLimitDecision{
+		Allowed:   res[0] == 1,
+		Limit:     limit,
+		Remaining: limit - int(res[1]),
+		Reset:     time.Duration(res[2]) * time.Millisecond,
+	}, nil
+}
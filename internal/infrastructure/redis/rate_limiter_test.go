@@ -0,0 +1,60 @@
+package redisstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	redisstore "fxrates-service/internal/infrastructure/redis"
+	miniredis "github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter_SlidingWindow(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := redisstore.NewRateLimiter(redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	l.Now = func() time.Time { return now }
+	ctx := context.Background()
+	const window = time.Minute
+
+	d, err := l.Allow(ctx, "c1", 2, window)
+	require.NoError(t, err)
+	require.True(t, d.Allowed)
+	require.Equal(t, 1, d.Remaining)
+	require.Equal(t, window, d.Reset)
+
+	now = now.Add(20 * time.Second)
+	d, err = l.Allow(ctx, "c1", 2, window)
+	require.NoError(t, err)
+	require.True(t, d.Allowed)
+	require.Equal(t, 0, d.Remaining)
+
+	now = now.Add(10 * time.Second)
+	d, err = l.Allow(ctx, "c1", 2, window)
+	require.NoError(t, err)
+	require.False(t, d.Allowed)
+	require.Equal(t, 0, d.Remaining)
+	require.Equal(t, 30*time.Second, d.Reset)
+
+	// Other keys have their own budget.
+	d, err = l.Allow(ctx, "c2", 2, window)
+	require.NoError(t, err)
+	require.True(t, d.Allowed)
+
+	// The first request leaves the window, the second is still in it.
+	now = now.Add(30 * time.Second)
+	d, err = l.Allow(ctx, "c1", 2, window)
+	require.NoError(t, err)
+	require.True(t, d.Allowed)
+	require.Equal(t, 0, d.Remaining)
+	require.Equal(t, 20*time.Second, d.Reset)
+
+	require.True(t, mr.Exists("rate_limit:c1"))
+	mr.FastForward(window)
+	require.False(t, mr.Exists("rate_limit:c1"))
+}
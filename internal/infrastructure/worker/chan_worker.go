@@ -48,13 +48,19 @@ func (w *ChanWorker) processOne(ctx context.Context, m UpdateMsg) {
 	defer func() {
 		if r := recover(); r != nil {
 			logx.L().Warn("chan_worker.panic", zap.Any("r", r))
-			msg := fmt.Sprint(r)
-			_ = w.svc.CompleteQuoteUpdate(ctx, m.ID, func(context.Context) (domain.Quote, error) {
-				return domain.Quote{}, fmt.Errorf("panic: %s", msg)
-			}, "chan")
+			_ = w.svc.FailQuoteUpdate(ctx, m.ID, fmt.Errorf("panic: %v", r))
 		}
 	}()
-	c, cancel := context.WithTimeout(ctx, 5*time.Second)
+	ok, err := w.svc.StartQuoteUpdate(ctx, m.ID)
+	if err != nil {
+		logx.L().Warn("chan_worker.claim_failed", zap.String("update_id", m.ID), zap.Error(err))
+		return
+	}
+	if !ok {
+		logx.L().Info("chan_worker.skip_not_queued", zap.String("update_id", m.ID))
+		return
+	}
+	c, cancel := context.WithTimeout(application.ContextWithTraceID(ctx, m.TraceID), 5*time.Second)
 	defer cancel()
 	_ = w.svc.CompleteQuoteUpdate(c, m.ID, func(cx context.Context) (domain.Quote, error) {
 		return w.svc.FetchQuote(cx, m.Pair)
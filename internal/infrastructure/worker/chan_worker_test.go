@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChanWorker_SkipsJobsThatAreNoLongerQueued(t *testing.T) {
+	j := &memJobs{jobs: map[string]domain.QuoteUpdate{
+		"queued":   {ID: "queued", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusQueued},
+		"canceled": {ID: "canceled", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusCanceled},
+	}}
+	q := &memQuotes{}
+	svc := application.NewService(q, j, &memProvider{price: 1.1}, nil)
+	w := NewChanWorker(svc, nil)
+
+	w.processOne(context.Background(), UpdateMsg{ID: "canceled", Pair: "EUR/USD"})
+	require.Equal(t, domain.QuoteUpdateStatusCanceled, j.status("canceled"))
+	require.False(t, q.has("EUR/USD"))
+
+	w.processOne(context.Background(), UpdateMsg{ID: "queued", Pair: "EUR/USD"})
+	require.Equal(t, domain.QuoteUpdateStatusDone, j.status("queued"))
+	require.True(t, q.has("EUR/USD"))
+}
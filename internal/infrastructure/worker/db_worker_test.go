@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fxrates-service/internal/application"
+	"fxrates-service/internal/domain"
+	"fxrates-service/internal/infrastructure/provider"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBWorker_SimTimeoutDoesNotStall(t *testing.T) {
+	j := &memJobs{jobs: map[string]domain.QuoteUpdate{
+		"hangs": {ID: "hangs", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusQueued},
+	}}
+	sim, err := provider.NewSim(provider.SimConfig{TimeoutRate: 1, Hang: 20 * time.Millisecond})
+	require.NoError(t, err)
+	svc := application.NewService(&memQuotes{}, j, sim, nil)
+	w := NewDBWorker(svc, 5*time.Millisecond, 10, nil)
+
+	// The worker's own context has no deadline, as in production.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Start(ctx)
+
+	require.Eventually(t, func() bool { return j.status("hangs") == domain.QuoteUpdateStatusFailed },
+		time.Second, 5*time.Millisecond)
+
+	// The worker is free again and takes the next job.
+	j.mu.Lock()
+	j.jobs["next"] = domain.QuoteUpdate{ID: "next", Pair: "EUR/USD", Status: domain.QuoteUpdateStatusQueued}
+	j.mu.Unlock()
+	require.Eventually(t, func() bool { return j.status("next") == domain.QuoteUpdateStatusFailed },
+		time.Second, 5*time.Millisecond)
+}
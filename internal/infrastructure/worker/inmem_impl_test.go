@@ -77,6 +77,9 @@ type memQuotes struct {
 func (m *memQuotes) GetLast(context.Context, string) (domain.Quote, error) {
 	return domain.Quote{}, nil
 }
+func (m *memQuotes) GetMany(context.Context, []string) ([]domain.Quote, error) {
+	return nil, nil
+}
 func (m *memQuotes) Upsert(_ context.Context, q domain.Quote) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -92,6 +95,9 @@ func (m *memQuotes) AppendHistory(_ context.Context, h domain.QuoteHistory) erro
 	m.history = append(m.history, h)
 	return nil
 }
+func (m *memQuotes) HistorySince(context.Context, int64, []string, int) ([]domain.QuoteHistory, error) {
+	return nil, nil
+}
 func (m *memQuotes) has(pair string) bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -118,6 +124,25 @@ func (m *memJobs) UpdateStatus(_ context.Context, id string, st domain.QuoteUpda
 	m.jobs[id] = j
 	return nil
 }
+func (m *memJobs) ClaimByID(_ context.Context, id string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[id]
+	if !ok || j.Status != domain.QuoteUpdateStatusQueued {
+		return false, nil
+	}
+	j.Status = domain.QuoteUpdateStatusProcessing
+	m.jobs[id] = j
+	return true, nil
+}
+func (m *memJobs) CancelQueued(context.Context, string) (bool, error)  { return false, nil }
+func (m *memJobs) RequeueFailed(context.Context, string) (bool, error) { return false, nil }
+func (m *memJobs) List(context.Context, domain.QuoteUpdateFilter) ([]domain.QuoteUpdate, error) {
+	return nil, nil
+}
+func (m *memJobs) CountByStatus(context.Context, domain.QuoteUpdateFilter) (map[domain.QuoteUpdateStatus]int64, error) {
+	return nil, nil
+}
 func (m *memJobs) ListQueuedIDs() []string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
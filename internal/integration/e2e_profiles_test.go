@@ -30,6 +30,9 @@ const (
 	statusPollInterval = 250 * time.Millisecond
 	requestContentType = "application/json"
 	idempotencyHeader  = "X-Idempotency-Key"
+
+	// EUR/USD rate recorded in ops/fixtures/provider for the exchangeratesapi replay run
+	expectedReplayPrice = 1.030375
 )
 
 type quoteUpdateResponse struct {
@@ -93,6 +96,21 @@ func TestE2E_GRPCProfile(t *testing.T) {
 	assertApproxEqual(t, price, expectedFakePrice, 1e-4)
 }
 
+func TestE2E_DBProfile_Replay(t *testing.T) {
+	if !isE2EEnabled(t) {
+		t.Skip("E2E_PROFILES not enabled or docker compose unavailable")
+	}
+	// Real provider adapter against recorded responses; no key or network needed.
+	cleanup := startProfile(t, "db", "PROVIDER=exchangeratesapi", "HTTP_RECORD_MODE=replay")
+	defer cleanup()
+
+	waitForReady(t, baseURL)
+	updateID := postUpdate(t, baseURL, "EUR/USD", "e2e-db-replay")
+	waitForDone(t, baseURL, updateID)
+	price := getLastQuote(t, baseURL, "EUR/USD")
+	assertApproxEqual(t, price, expectedReplayPrice, 1e-4)
+}
+
 func isE2EEnabled(t *testing.T) bool {
 	t.Helper()
 	if os.Getenv("E2E_PROFILES") != "1" {
@@ -109,31 +127,33 @@ func isE2EEnabled(t *testing.T) bool {
 	return true
 }
 
-func startProfile(t *testing.T, profile string) func() {
+// startProfile brings up a compose profile; env entries (KEY=VALUE) override the fake provider defaults.
+func startProfile(t *testing.T, profile string, env ...string) func() {
 	t.Helper()
 	composeFile := repoPath(t, "ops", "docker", "docker-compose.yml")
 
 	// Ensure clean slate (best-effort)
-	_ = runCompose(t, composeDownTimeout, "down -v", profile, composeFile)
+	_ = runCompose(t, composeDownTimeout, "down -v", profile, composeFile, env...)
 
 	// Up with build
-	if err := runCompose(t, composeUpTimeout, "up -d --build", profile, composeFile); err != nil {
+	if err := runCompose(t, composeUpTimeout, "up -d --build", profile, composeFile, env...); err != nil {
 		t.Fatalf("failed to start profile %q: %v", profile, err)
 	}
 	return func() {
-		_ = runCompose(t, composeDownTimeout, "down -v", profile, composeFile)
+		_ = runCompose(t, composeDownTimeout, "down -v", profile, composeFile, env...)
 	}
 }
 
-func runCompose(t *testing.T, timeout time.Duration, action, profile, composeFile string) error {
+func runCompose(t *testing.T, timeout time.Duration, action, profile, composeFile string, env ...string) error {
 	t.Helper()
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	args := []string{"compose", "-f", composeFile, "--profile", profile}
 	args = append(args, strings.Split(action, " ")...)
 	cmd := exec.CommandContext(ctx, "docker", args...)
-	// Force fake provider for deterministic behavior
+	// Force fake provider for deterministic behavior unless the test overrides it
 	cmd.Env = append(os.Environ(), "PROVIDER=fake")
+	cmd.Env = append(cmd.Env, env...)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("docker %s failed: %w\nOutput:\n%s", strings.Join(args, " "), err, string(out))
@@ -181,7 +201,7 @@ func postUpdate(t *testing.T, baseURL, pair, idem string) string {
 	}
 	var out quoteUpdateResponse
 	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		t.Fatalf("failed to decode response: %v")
+		t.Fatalf("failed to decode response: %v", err)
 	}
 	if out.UpdateID == "" {
 		t.Fatalf("missing update_id in response")
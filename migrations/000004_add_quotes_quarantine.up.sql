@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS quotes_quarantine (
+  id           BIGSERIAL PRIMARY KEY,
+  pair         TEXT          NOT NULL,
+  price        NUMERIC(18,6) NOT NULL,
+  quoted_at    TIMESTAMPTZ   NOT NULL,
+  source       TEXT          NOT NULL,
+  update_id    UUID REFERENCES quote_updates(id) ON DELETE SET NULL,
+  reason       TEXT          NOT NULL,
+  detail       TEXT          NOT NULL DEFAULT '',
+  inserted_at  TIMESTAMPTZ   NOT NULL DEFAULT now(),
+  released_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_quotes_quarantine_pending
+  ON quotes_quarantine (pair, inserted_at DESC)
+  WHERE released_at IS NULL;
+
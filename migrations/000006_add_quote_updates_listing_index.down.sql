@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_quote_updates_requested;
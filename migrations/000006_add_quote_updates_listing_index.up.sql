@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_quote_updates_requested ON quote_updates(requested_at DESC, id DESC);
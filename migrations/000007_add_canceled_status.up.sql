@@ -0,0 +1,4 @@
+ALTER TABLE quote_updates DROP CONSTRAINT IF EXISTS quote_updates_status_check;
+ALTER TABLE quote_updates
+  ADD CONSTRAINT quote_updates_status_check
+  CHECK (status IN ('queued','processing','done','failed','canceled'));
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS quote_update_attempts (
+  update_id    UUID        NOT NULL REFERENCES quote_updates(id) ON DELETE CASCADE,
+  attempt      INT         NOT NULL,
+  worker       TEXT        NOT NULL,
+  started_at   TIMESTAMPTZ NOT NULL,
+  finished_at  TIMESTAMPTZ,
+  error        TEXT,
+  PRIMARY KEY (update_id, attempt)
+);